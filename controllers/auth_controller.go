@@ -2,7 +2,6 @@ package controllers
 
 import (
 	"encoding/json"
-	"hotel_management_system/database"
 	"hotel_management_system/models"
 	"log"
 	"net/http"
@@ -27,9 +26,10 @@ var jwtKey = []byte(os.Getenv("JWT_KEY"))
 // @Param	role	  body string  true  "Role"
 // @Success 201 {string} string "User registered successfully"
 // @Failure 400 {string} string "Invalid input"
+// @Failure 409 {string} string "Username or email taken by another or a deleted user"
 // @Failure 500 {string} string "Internal server error"
 // @Router /register [post]
-func RegisterHandler(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) RegisterHandler(w http.ResponseWriter, r *http.Request) {
 	var user models.User
 	err := json.NewDecoder(r.Body).Decode(&user)
 	if err != nil {
@@ -54,8 +54,8 @@ func RegisterHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("Registering user: %v", user)
 
-	if result := database.DB.Create(&user); result.Error != nil {
-		http.Error(w, "Failed to create user: "+result.Error.Error(), http.StatusInternalServerError)
+	if err := h.store.Users().Create(&user); err != nil {
+		writeUserError(w, err, "Failed to create user: "+err.Error())
 		return
 	}
 
@@ -76,7 +76,7 @@ func RegisterHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {string} string "Invalid username or password"
 // @Failure 500 {string} string "Internal server error"
 // @Router /login [post]
-func LoginHandler(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	var reqUser struct {
 		Username string `json:"username"`
 		Password string `json:"password"`
@@ -87,13 +87,13 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var user models.User
-	if result := database.DB.Where("username = ?", reqUser.Username).First(&user); result.Error != nil {
+	user, err := h.store.Users().FindByUsername(reqUser.Username)
+	if err != nil {
 		http.Error(w, "Invalid username or password", http.StatusUnauthorized)
 		return
 	}
 
-	log.Printf("User found: %v", user)
+	log.Printf("User found: %v", *user)
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(reqUser.Password))
 	if err != nil {
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// parseDate accepts either an RFC3339 timestamp or a plain YYYY-MM-DD date.
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// SearchAvailability godoc
+// @Summary Search available rooms
+// @Description Get the rooms that are in service and free for the whole date range, grouped by room type with the price of the dearest night and the total stay price of the type in minor units of its currency
+// @Tags Reservation
+// @Produce  json
+// @Param   start      query string  true  "Start date (RFC3339 or YYYY-MM-DD)"
+// @Param   end        query string  true  "End date (RFC3339 or YYYY-MM-DD)"
+// @Param   type       query string  false "Room type name"
+// @Param   guests     query int     false "Number of adults"
+// @Param   children   query int     false "Number of children"
+// @Param   max_price  query number  false "Maximum price of any night of the stay, before taxes, in the base currency"
+// @Success 200 {array} service.AvailableRoomType
+// @Failure 400 {string} string "Invalid input"
+// @Failure 422 {string} string "Missing exchange rate"
+// @Failure 500 {string} string "Internal server error"
+// @Router /availability [get]
+func (h *Handler) SearchAvailability(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	startDate, err := parseDate(params.Get("start"))
+	if err != nil {
+		http.Error(w, "Invalid start date format.", http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseDate(params.Get("end"))
+	if err != nil {
+		http.Error(w, "Invalid end date format.", http.StatusBadRequest)
+		return
+	}
+
+	query := service.AvailabilityQuery{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Type:      params.Get("type"),
+	}
+
+	if guests := params.Get("guests"); guests != "" {
+		query.Guests, err = strconv.Atoi(guests)
+		if err != nil || query.Guests < 1 {
+			http.Error(w, "Invalid number of guests.", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if children := params.Get("children"); children != "" {
+		query.Children, err = strconv.Atoi(children)
+		if err != nil || query.Children < 0 {
+			http.Error(w, "Invalid number of children.", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if maxPrice := params.Get("max_price"); maxPrice != "" {
+		price, err := strconv.ParseFloat(maxPrice, 64)
+		if err != nil || price <= 0 {
+			http.Error(w, "Invalid max price.", http.StatusBadRequest)
+			return
+		}
+		query.MaxPrice = service.ToMinorUnits(price, service.BaseCurrency())
+	}
+
+	result, err := service.SearchAvailability(h.store, query)
+	if errors.Is(err, service.ErrInvalidDateRange) {
+		http.Error(w, "End date must be after start date.", http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, service.ErrNoExchangeRate) {
+		http.Error(w, "Cannot convert prices: "+err.Error()+".", http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to search availability.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"encoding/json"
+	"hotel_management_system/models"
+	service "hotel_management_system/services"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// CancelReservation godoc
+// @Summary Cancel a reservation
+// @Description Cancel a reservation, post the penalty of its rate plan's cancellation policy to the folio and refund the rest of what was paid. Amounts are in minor units.
+// @Tags Reservation
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Success 200 {object} service.CancellationQuote
+// @Failure 400 {string} string "Invalid reservation ID"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Reservation cannot be cancelled"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/cancel [post]
+func (h *Handler) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.store.Reservations().FindByID(uint(reservID)); err != nil {
+		http.Error(w, "Reservation not found", http.StatusNotFound)
+		return
+	}
+
+	claims := r.Context().Value("user").(*models.Claims)
+	reservation, quote, err := service.CancelReservation(h.store, uint(reservID), claims.UserID)
+	if err != nil {
+		writeReservationError(w, err, "Failed to cancel reservation")
+		return
+	}
+
+	refunds, err := service.RefundCancelled(h.store, h.payments, reservation.ID, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to refund reservation %d: %v", reservation.ID, err)
+	}
+	// Report what was actually refunded rather than the estimate.
+	quote.Refund = 0
+	for _, refund := range refunds {
+		if refund.Status == "refunded" {
+			quote.Refund += refund.Amount
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(quote)
+}
+
+// PreviewCancellation godoc
+// @Summary Preview the cost of cancelling a reservation
+// @Description Tell what cancelling a reservation now would cost under its rate plan's cancellation policy and how much would be refunded, without cancelling it. Amounts are in minor units.
+// @Tags Reservation
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Success 200 {object} service.CancellationQuote
+// @Failure 400 {string} string "Invalid reservation ID"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Reservation cannot be cancelled"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/cancel/preview [get]
+func (h *Handler) PreviewCancellation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.store.Reservations().FindByID(uint(reservID)); err != nil {
+		http.Error(w, "Reservation not found", http.StatusNotFound)
+		return
+	}
+
+	quote, err := service.PreviewCancellation(h.store, uint(reservID))
+	if err != nil {
+		writeReservationError(w, err, "Failed to preview cancellation")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(quote)
+}
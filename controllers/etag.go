@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"errors"
+	"hotel_management_system/middleware"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// UserVersion is the middleware.VersionFunc of /users/{user_id}.
+func (h *Handler) UserVersion(r *http.Request) (uint, error) {
+	userID, err := strconv.Atoi(mux.Vars(r)["user_id"])
+	if err != nil {
+		return 0, err
+	}
+	user, err := h.store.Users().FindByID(uint(userID))
+	if err != nil {
+		return 0, err
+	}
+	return user.Version, nil
+}
+
+// ProfileVersion is the middleware.VersionFunc of the profile of the
+// logged-in user.
+func (h *Handler) ProfileVersion(r *http.Request) (uint, error) {
+	claims := r.Context().Value("user").(*models.Claims)
+	user, err := h.store.Users().FindByID(claims.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return user.Version, nil
+}
+
+// RoomVersion is the middleware.VersionFunc of /rooms/{room_id}.
+func (h *Handler) RoomVersion(r *http.Request) (uint, error) {
+	roomID, err := strconv.Atoi(mux.Vars(r)["room_id"])
+	if err != nil {
+		return 0, err
+	}
+	room, err := h.store.Rooms().FindByID(uint(roomID))
+	if err != nil {
+		return 0, err
+	}
+	return room.Version, nil
+}
+
+// ReservationVersion is the middleware.VersionFunc of the reservation
+// routes with a {reservation_id}.
+func (h *Handler) ReservationVersion(r *http.Request) (uint, error) {
+	reservID, err := strconv.Atoi(mux.Vars(r)["reservation_id"])
+	if err != nil {
+		return 0, err
+	}
+	reservation, err := h.store.Reservations().FindByID(uint(reservID))
+	if err != nil {
+		return 0, err
+	}
+	return reservation.Version, nil
+}
+
+// setETag sends the version a write left a resource at, so the client can
+// make its next change without reading the resource again.
+func setETag(w http.ResponseWriter, version uint) {
+	w.Header().Set("ETag", middleware.ETagOf(version))
+}
+
+// writeVersionConflict answers 412 for a save that lost the race against
+// another change made after the If-Match header was checked.
+func writeVersionConflict(w http.ResponseWriter) {
+	http.Error(w, "Resource was changed by someone else; reload it and try again.", http.StatusPreconditionFailed)
+}
+
+// writeSaveError answers a failed save of a versioned resource: 412 for a
+// version conflict and 500 with message for anything else.
+func writeSaveError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		writeVersionConflict(w)
+		return
+	}
+	http.Error(w, message, http.StatusInternalServerError)
+}
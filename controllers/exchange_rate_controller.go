@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hotel_management_system/models"
+	service "hotel_management_system/services"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// GetExchangeRates godoc
+// @Summary Get exchange rates
+// @Description Get the uploaded daily exchange rates, each the value of one unit of the currency in the base currency
+// @Tags Exchange Rates
+// @Produce  json
+// @Param   currency  query string  false  "ISO 4217 currency code"
+// @Success 200 {array} models.ExchangeRate
+// @Failure 500 {string} string "Internal server error"
+// @Router /exchange-rates [get]
+func (h *Handler) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	currency := strings.ToUpper(r.URL.Query().Get("currency"))
+	rates, err := h.store.ExchangeRates().FindAll(currency)
+	if err != nil {
+		http.Error(w, "Failed to fetch exchange rates.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"base_currency": service.BaseCurrency(),
+		"rates":         rates,
+	})
+}
+
+// UploadExchangeRates godoc
+// @Summary Upload exchange rates
+// @Description Upload daily exchange rates as a JSON array or as text/csv rows of currency,date (YYYY-MM-DD),rate. A rate is the value of one unit of the currency in the base currency and replaces any rate already uploaded for the same currency and day.
+// @Tags Exchange Rates
+// @Accept  json
+// @Accept  text/csv
+// @Produce  json
+// @Param   rates  body []models.ExchangeRate  true  "Exchange rates"
+// @Success 201 {array} models.ExchangeRate
+// @Failure 400 {string} string "Invalid input"
+// @Failure 500 {string} string "Internal server error"
+// @Router /exchange-rates [post]
+func (h *Handler) UploadExchangeRates(w http.ResponseWriter, r *http.Request) {
+	var rates []models.ExchangeRate
+	var err error
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+		rates, err = service.ParseExchangeRatesCSV(r.Body)
+	} else {
+		err = json.NewDecoder(r.Body).Decode(&rates)
+	}
+	if err != nil || len(rates) == 0 {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+
+	if err := service.SaveExchangeRates(h.store, rates); err != nil {
+		if errors.Is(err, service.ErrInvalidCurrency) || errors.Is(err, service.ErrInvalidExchangeRate) {
+			http.Error(w, "Invalid exchange rate: "+err.Error()+".", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to save exchange rates.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rates)
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetReservationFolio godoc
+// @Summary Get the folio of a reservation
+// @Description Get the charges and payments posted to a reservation with the running balance, in minor units
+// @Tags Folio
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Success 200 {object} models.Folio
+// @Failure 400 {string} string "Invalid reservation ID"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/folio [get]
+func (h *Handler) GetReservationFolio(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	folio, err := service.GetFolio(h.store, uint(reservID))
+	if err != nil {
+		writeFolioError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(folio)
+}
+
+// PostReservationCharge godoc
+// @Summary Post a charge to a reservation
+// @Description Post a debit (minibar, room-service, parking, late-checkout, ...) or credit (payment, adjustment) to the folio of a reservation. Amount is positive, in minor units.
+// @Tags Folio
+// @Accept  json
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Param   charge  body models.FolioLine  true  "Folio line"
+// @Success 201 {object} models.FolioLine
+// @Failure 400 {string} string "Invalid charge"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/charges [post]
+func (h *Handler) PostReservationCharge(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	var line models.FolioLine
+	err = json.NewDecoder(r.Body).Decode(&line)
+	if err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	if line.Type == "" {
+		line.Type = "debit"
+	}
+
+	claims := r.Context().Value("user").(*models.Claims)
+	if err := service.PostCharge(h.store, uint(reservID), &line, claims.UserID); err != nil {
+		writeFolioError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(line)
+}
+
+func writeFolioError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		http.Error(w, "Reservation not found.", http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidCharge):
+		http.Error(w, "Invalid charge: type must be debit or credit, category must be known and amount positive.", http.StatusBadRequest)
+	default:
+		http.Error(w, "Failed to update folio.", http.StatusInternalServerError)
+	}
+}
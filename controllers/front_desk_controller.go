@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"encoding/json"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// CheckInReservation godoc
+// @Summary Check in a reservation
+// @Description Check in a confirmed reservation during its stay dates and mark its room as occupied
+// @Tags Reservation
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Success 200 {object} models.Reservation
+// @Failure 400 {string} string "Invalid reservation ID"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Reservation cannot be checked in"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/check-in [post]
+func (h *Handler) CheckInReservation(w http.ResponseWriter, r *http.Request) {
+	h.frontDeskAction(w, r, service.CheckIn)
+}
+
+// CheckOutReservation godoc
+// @Summary Check out a reservation
+// @Description Check out a checked-in reservation and send its room to cleaning. The folio balance must be zero unless an admin passes override=true.
+// @Tags Reservation
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Param   override  query bool  false  "Check out despite an unsettled folio (admin only)"
+// @Success 200 {object} models.Reservation
+// @Failure 400 {string} string "Invalid reservation ID"
+// @Failure 403 {string} string "Only admins can override the folio balance"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Reservation cannot be checked out"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/check-out [post]
+func (h *Handler) CheckOutReservation(w http.ResponseWriter, r *http.Request) {
+	override := r.URL.Query().Get("override") == "true"
+	claims := r.Context().Value("user").(*models.Claims)
+	if override && claims.Role != "admin" {
+		http.Error(w, "Only admins can override the folio balance.", http.StatusForbidden)
+		return
+	}
+
+	h.frontDeskAction(w, r, func(store repository.Store, id uint, actorID uint) (*models.Reservation, error) {
+		return service.CheckOut(store, id, actorID, override)
+	})
+}
+
+// MoveReservation godoc
+// @Summary Move a reservation to another room
+// @Description Move a reservation to the room given by room_number. An upcoming stay moves as a whole. A checked-in guest moves now: the stay is split into segments per room, the room left goes to cleaning and the new room, which must be ready, becomes occupied. The new room must be free for the rest of the stay and fit the party. The price and room type of the stay do not change and each night stays attributed to the room it was spent in.
+// @Tags Reservation
+// @Accept  json
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Param   room_number  body string  true  "Number of the new room"
+// @Success 200 {object} models.Reservation
+// @Failure 400 {string} string "Invalid input or same room"
+// @Failure 404 {string} string "Reservation or room not found"
+// @Failure 409 {string} string "Reservation cannot move or room unavailable"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/move [post]
+func (h *Handler) MoveReservation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		RoomNumber string `json:"room_number"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.RoomNumber == "" {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	room, err := h.store.Rooms().FindByNumber(input.RoomNumber)
+	if err != nil {
+		http.Error(w, "Room not found.", http.StatusNotFound)
+		return
+	}
+
+	h.frontDeskAction(w, r, func(store repository.Store, id uint, actorID uint) (*models.Reservation, error) {
+		return service.MoveReservation(store, id, room.ID, actorID)
+	})
+}
+
+func (h *Handler) frontDeskAction(w http.ResponseWriter, r *http.Request, action func(store repository.Store, id uint, actorID uint) (*models.Reservation, error)) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.store.Reservations().FindByID(uint(reservID)); err != nil {
+		http.Error(w, "Reservation not found", http.StatusNotFound)
+		return
+	}
+
+	claims := r.Context().Value("user").(*models.Claims)
+	reservation, err := action(h.store, uint(reservID), claims.UserID)
+	if err != nil {
+		writeReservationError(w, err, "Failed to update reservation")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservation)
+}
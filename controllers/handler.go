@@ -0,0 +1,17 @@
+package controllers
+
+import (
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+)
+
+// Handler holds the dependencies shared by every HTTP handler.
+type Handler struct {
+	store      repository.Store
+	payments   service.PaymentGateway
+	assignment service.RoomAssignmentStrategy
+}
+
+func NewHandler(store repository.Store, payments service.PaymentGateway, assignment service.RoomAssignmentStrategy) *Handler {
+	return &Handler{store: store, payments: payments, assignment: assignment}
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// newTestHandler returns a Handler on a memory store holding a guest with ID
+// 1 and rooms 101 and 102 of a room type sold at 100.00 USD a night.
+func newTestHandler(t *testing.T) (*Handler, repository.Store) {
+	t.Helper()
+	store := repository.NewMemoryStore()
+	roomType := &models.RoomType{Name: "double", BasePrice: 10000, Currency: "USD", MaxOccupancy: 2, BaseOccupancy: 2}
+	if err := store.RoomTypes().Create(roomType); err != nil {
+		t.Fatal(err)
+	}
+	for _, number := range []string{"101", "102"} {
+		if err := store.Rooms().Create(&models.Room{Number: number, RoomTypeID: roomType.ID, Status: "available"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Users().Create(&models.User{Username: "guest", Email: "guest@example.com", Role: "customer"}); err != nil {
+		t.Fatal(err)
+	}
+	return NewHandler(store, service.NewFakeGateway(), service.LowestFloorStrategy{}), store
+}
+
+// serve runs handler on a request with body as JSON, made by an admin and
+// routed with the given path variables.
+func serve(handler http.HandlerFunc, method string, body interface{}, vars map[string]string) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	r := httptest.NewRequest(method, "/", bytes.NewReader(payload))
+	r = mux.SetURLVars(r, vars)
+	r = r.WithContext(context.WithValue(r.Context(), "user", &models.Claims{Username: "admin", UserID: 1, Role: "admin"}))
+	w := httptest.NewRecorder()
+	handler(w, r)
+	return w
+}
+
+// withIfMatch runs handler on requests carrying an If-Match header of tag.
+func withIfMatch(tag string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set("If-Match", tag)
+		handler(w, r)
+	}
+}
+
+// atVersion runs handler as if If-Match had matched version.
+func atVersion(version uint, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r.WithContext(context.WithValue(r.Context(), "version", version)))
+	}
+}
+
+// assertJSON fails t unless body holds the same JSON value as want.
+func assertJSON(t *testing.T, body *bytes.Buffer, want string) {
+	t.Helper()
+	var got, expected interface{}
+	if err := json.Unmarshal(body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", body.String(), err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %s, want %s", body.String(), want)
+	}
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// GetHousekeepingTasks godoc
+// @Summary Get housekeeping tasks
+// @Description Get every housekeeping task, optionally filtered by status
+// @Tags Housekeeping
+// @Produce  json
+// @Param   status  query string  false  "Task status (open, claimed, done)"
+// @Success 200 {array} models.HousekeepingTask
+// @Failure 500 {string} string "Internal server error"
+// @Router /housekeeping/tasks [get]
+func (h *Handler) GetHousekeepingTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := h.store.HousekeepingTasks().FindAll(r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, "Failed to fetch housekeeping tasks.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tasks)
+}
+
+// ClaimHousekeepingTask godoc
+// @Summary Claim a housekeeping task
+// @Description Assign an open housekeeping task to the logged-in housekeeper
+// @Tags Housekeeping
+// @Produce  json
+// @Param   task_id  path int  true  "Task ID"
+// @Success 200 {object} models.HousekeepingTask
+// @Failure 400 {string} string "Invalid task ID"
+// @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "Task already claimed or done"
+// @Failure 500 {string} string "Internal server error"
+// @Router /housekeeping/tasks/{task_id}/claim [put]
+func (h *Handler) ClaimHousekeepingTask(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	taskID, err := strconv.Atoi(params["task_id"])
+	if err != nil {
+		http.Error(w, "Invalid task id.", http.StatusBadRequest)
+		return
+	}
+
+	claims := r.Context().Value("user").(*models.Claims)
+	task, err := service.ClaimTask(h.store, uint(taskID), claims.UserID)
+	if err != nil {
+		writeHousekeepingError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(task)
+}
+
+// CompleteHousekeepingTask godoc
+// @Summary Complete a housekeeping task
+// @Description Mark a housekeeping task as done and its room as clean and available. Admins may complete tasks claimed by someone else.
+// @Tags Housekeeping
+// @Produce  json
+// @Param   task_id  path int  true  "Task ID"
+// @Success 200 {object} models.HousekeepingTask
+// @Failure 400 {string} string "Invalid task ID"
+// @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "Task claimed by someone else or done"
+// @Failure 500 {string} string "Internal server error"
+// @Router /housekeeping/tasks/{task_id}/complete [put]
+func (h *Handler) CompleteHousekeepingTask(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	taskID, err := strconv.Atoi(params["task_id"])
+	if err != nil {
+		http.Error(w, "Invalid task id.", http.StatusBadRequest)
+		return
+	}
+
+	claims := r.Context().Value("user").(*models.Claims)
+	task, err := service.CompleteTask(h.store, uint(taskID), claims.UserID, claims.Role == "admin")
+	if err != nil {
+		writeHousekeepingError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(task)
+}
+
+// GetHousekeepingBoard godoc
+// @Summary Get the housekeeping board
+// @Description Get every room with its status and the housekeeping tasks outstanding on the given day
+// @Tags Housekeeping
+// @Produce  json
+// @Param   date  query string  false  "Day (YYYY-MM-DD), defaults to today"
+// @Success 200 {array} service.BoardEntry
+// @Failure 400 {string} string "Invalid date"
+// @Failure 500 {string} string "Internal server error"
+// @Router /housekeeping/board [get]
+func (h *Handler) GetHousekeepingBoard(w http.ResponseWriter, r *http.Request) {
+	day := time.Now()
+	if date := r.URL.Query().Get("date"); date != "" {
+		var err error
+		day, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			http.Error(w, "Invalid date format.", http.StatusBadRequest)
+			return
+		}
+	}
+
+	board, err := service.HousekeepingBoard(h.store, day)
+	if err != nil {
+		http.Error(w, "Failed to build housekeeping board.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(board)
+}
+
+func writeHousekeepingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		http.Error(w, "Task not found.", http.StatusNotFound)
+	case errors.Is(err, service.ErrTaskAlreadyClaimed):
+		http.Error(w, "Task is claimed by someone else.", http.StatusConflict)
+	case errors.Is(err, service.ErrTaskDone):
+		http.Error(w, "Task is already done.", http.StatusConflict)
+	default:
+		http.Error(w, "Failed to update housekeeping task.", http.StatusInternalServerError)
+	}
+}
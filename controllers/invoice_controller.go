@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// GetInvoice godoc
+// @Summary Get an invoice
+// @Description Get an issued invoice as JSON, or as a PDF document when format=pdf or the Accept header asks for application/pdf. Amounts are in minor units.
+// @Tags Invoice
+// @Produce  json
+// @Produce  application/pdf
+// @Param   invoice_id  path int  true  "Invoice ID"
+// @Param   format  query string  false  "json (default) or pdf"
+// @Success 200 {object} models.Invoice
+// @Failure 400 {string} string "Invalid invoice ID"
+// @Failure 404 {string} string "Invoice not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /invoices/{invoice_id} [get]
+func (h *Handler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	invoiceID, err := strconv.Atoi(params["invoice_id"])
+	if err != nil {
+		http.Error(w, "Invalid invoice id.", http.StatusBadRequest)
+		return
+	}
+
+	invoice, err := h.store.Invoices().FindByID(uint(invoiceID))
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	writeInvoice(w, r, invoice)
+}
+
+// GetReservationInvoice godoc
+// @Summary Get the invoice of a reservation
+// @Description Get the invoice issued when a reservation was checked out, as JSON or PDF like GET /invoices/{invoice_id}
+// @Tags Invoice
+// @Produce  json
+// @Produce  application/pdf
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Param   format  query string  false  "json (default) or pdf"
+// @Success 200 {object} models.Invoice
+// @Failure 400 {string} string "Invalid reservation ID"
+// @Failure 404 {string} string "Reservation or invoice not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/invoice [get]
+func (h *Handler) GetReservationInvoice(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	invoice, err := service.FindInvoiceByReservation(h.store, uint(reservID))
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	writeInvoice(w, r, invoice)
+}
+
+// writeInvoice responds with invoice as a PDF when the request asks for one
+// and as JSON otherwise.
+func writeInvoice(w http.ResponseWriter, r *http.Request, invoice *models.Invoice) {
+	if r.URL.Query().Get("format") == "pdf" || strings.Contains(r.Header.Get("Accept"), "application/pdf") {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `inline; filename="invoice-`+invoice.Number+`.pdf"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write(service.RenderInvoicePDF(invoice))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(invoice)
+}
+
+func writeInvoiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrNoInvoice):
+		http.Error(w, "Reservation has not been invoiced yet.", http.StatusNotFound)
+	case errors.Is(err, repository.ErrNotFound):
+		http.Error(w, "Invoice not found.", http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to fetch invoice.", http.StatusInternalServerError)
+	}
+}
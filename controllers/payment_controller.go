@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetReservationPayments godoc
+// @Summary Get the payments of a reservation
+// @Description Get the deposits, payments and refunds of a reservation, oldest first. Amounts are in minor units.
+// @Tags Payment
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Success 200 {array} models.Payment
+// @Failure 400 {string} string "Invalid reservation ID"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/payments [get]
+func (h *Handler) GetReservationPayments(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.store.Reservations().FindByID(uint(reservID)); err != nil {
+		http.Error(w, "Reservation not found", http.StatusNotFound)
+		return
+	}
+
+	payments, err := h.store.Payments().FindByReservation(uint(reservID))
+	if err != nil {
+		http.Error(w, "Failed to fetch payments.", http.StatusInternalServerError)
+		return
+	}
+	if payments == nil {
+		payments = []models.Payment{}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(payments)
+}
+
+// CreateReservationPayment godoc
+// @Summary Take a payment for a reservation
+// @Description Charge an amount in minor units to a payment source through the payment gateway and credit it to the folio
+// @Tags Payment
+// @Accept  json
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Param   payment  body object  true  "amount and payment source"
+// @Success 201 {object} models.Payment
+// @Failure 400 {string} string "Invalid input"
+// @Failure 402 {string} string "Payment declined"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/payments [post]
+func (h *Handler) CreateReservationPayment(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Amount        int64  `json:"amount"`
+		PaymentSource string `json:"payment_source"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+
+	claims := r.Context().Value("user").(*models.Claims)
+	payment, err := service.TakePayment(h.store, h.payments, uint(reservID), input.Amount, input.PaymentSource, claims.UserID)
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(payment)
+}
+
+func writePaymentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		http.Error(w, "Reservation not found.", http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidPayment):
+		http.Error(w, "Payment amount must be positive.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrPaymentRequired):
+		http.Error(w, "A payment source is required for the deposit.", http.StatusPaymentRequired)
+	case errors.Is(err, service.ErrPaymentDeclined):
+		http.Error(w, "Payment declined.", http.StatusPaymentRequired)
+	default:
+		http.Error(w, "Failed to process payment.", http.StatusInternalServerError)
+	}
+}
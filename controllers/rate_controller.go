@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// GetRatePlans godoc
+// @Summary Get all rate plans
+// @Description Get every rate plan with its percentage adjustment and nightly supplement (minor units)
+// @Tags Rates
+// @Produce  json
+// @Success 200 {array} models.RatePlan
+// @Failure 500 {string} string "Internal server error"
+// @Router /rate-plans [get]
+func (h *Handler) GetRatePlans(w http.ResponseWriter, r *http.Request) {
+	plans, err := h.store.Rates().FindAllPlans()
+	if err != nil {
+		http.Error(w, "Failed to fetch rate plans.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(plans)
+}
+
+// CreateRatePlan godoc
+// @Summary Create a rate plan
+// @Description Create a rate plan that adjusts the nightly price by a percentage and a fixed supplement in minor units of the base currency, with a deposit percentage, a free cancellation window in days before arrival and the cancellation policy applied after it (flexible, first-night, non-refundable)
+// @Tags Rates
+// @Accept  json
+// @Produce  json
+// @Param   plan  body models.RatePlan  true  "Rate plan"
+// @Success 201 {object} models.RatePlan
+// @Failure 400 {string} string "Invalid input"
+// @Failure 500 {string} string "Internal server error"
+// @Router /rate-plans [post]
+func (h *Handler) CreateRatePlan(w http.ResponseWriter, r *http.Request) {
+	var plan models.RatePlan
+	err := json.NewDecoder(r.Body).Decode(&plan)
+	if err != nil || plan.Code == "" || plan.Name == "" {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	if !service.NormalizePaymentTerms(&plan) {
+		http.Error(w, "Invalid deposit or cancellation policy.", http.StatusBadRequest)
+		return
+	}
+
+	plan.ID = 0
+	plan.CreatedAt = time.Now()
+	plan.UpdatedAt = time.Now()
+
+	if err := h.store.Rates().CreatePlan(&plan); err != nil {
+		http.Error(w, "Failed to create rate plan: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(plan)
+}
+
+// UpdateRatePlan godoc
+// @Summary Update a rate plan
+// @Description Update a rate plan. Reservations keep the prices they were booked at.
+// @Tags Rates
+// @Accept  json
+// @Produce  json
+// @Param   plan_id  path int  true  "Rate plan ID"
+// @Param   plan  body models.RatePlan  true  "Rate plan"
+// @Success 200 {object} models.RatePlan
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Rate plan not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /rate-plans/{plan_id} [put]
+func (h *Handler) UpdateRatePlan(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	planID, err := strconv.Atoi(params["plan_id"])
+	if err != nil {
+		http.Error(w, "Invalid rate plan id.", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.store.Rates().FindPlanByID(uint(planID))
+	if err != nil {
+		http.Error(w, "Rate plan not found.", http.StatusNotFound)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(plan)
+	if err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	if !service.NormalizePaymentTerms(plan) {
+		http.Error(w, "Invalid deposit or cancellation policy.", http.StatusBadRequest)
+		return
+	}
+
+	plan.ID = uint(planID)
+	plan.UpdatedAt = time.Now()
+
+	if err := h.store.Rates().SavePlan(plan); err != nil {
+		http.Error(w, "Failed to update rate plan: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(plan)
+}
+
+// GetRateOverrides godoc
+// @Summary Get all rate overrides
+// @Description Get every seasonal and day-of-week nightly rate override
+// @Tags Rates
+// @Produce  json
+// @Success 200 {array} models.RateOverride
+// @Failure 500 {string} string "Internal server error"
+// @Router /rate-overrides [get]
+func (h *Handler) GetRateOverrides(w http.ResponseWriter, r *http.Request) {
+	overrides, err := h.store.Rates().FindAllOverrides()
+	if err != nil {
+		http.Error(w, "Failed to fetch rate overrides.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(overrides)
+}
+
+// CreateRateOverride godoc
+// @Summary Create a rate override
+// @Description Replace the nightly price (minor units of the base currency) of the room type room_type_id between two dates and/or on some weekdays
+// @Tags Rates
+// @Accept  json
+// @Produce  json
+// @Param   override  body models.RateOverride  true  "Rate override"
+// @Success 201 {object} models.RateOverride
+// @Failure 400 {string} string "Invalid input"
+// @Failure 500 {string} string "Internal server error"
+// @Router /rate-overrides [post]
+func (h *Handler) CreateRateOverride(w http.ResponseWriter, r *http.Request) {
+	var override models.RateOverride
+	err := json.NewDecoder(r.Body).Decode(&override)
+	if err != nil || override.Amount <= 0 {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	if _, err := service.FindRoomType(h.store, override.RoomTypeID); err != nil {
+		writeRoomTypeError(w, err)
+		return
+	}
+	if !service.ValidWeekdays(override.Weekdays) {
+		http.Error(w, "Invalid weekdays.", http.StatusBadRequest)
+		return
+	}
+	if override.StartDate != nil && override.EndDate != nil && override.EndDate.Before(*override.StartDate) {
+		http.Error(w, "End date must not be before start date.", http.StatusBadRequest)
+		return
+	}
+
+	override.ID = 0
+	override.CreatedAt = time.Now()
+	override.UpdatedAt = time.Now()
+
+	if err := h.store.Rates().CreateOverride(&override); err != nil {
+		http.Error(w, "Failed to create rate override.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(override)
+}
+
+// DeleteRateOverride godoc
+// @Summary Delete a rate override
+// @Description Delete a rate override by ID
+// @Tags Rates
+// @Param   override_id  path int  true  "Rate override ID"
+// @Success 204 {string} string "No Content"
+// @Failure 400 {string} string "Invalid rate override ID"
+// @Failure 500 {string} string "Internal server error"
+// @Router /rate-overrides/{override_id} [delete]
+func (h *Handler) DeleteRateOverride(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	overrideID, err := strconv.Atoi(params["override_id"])
+	if err != nil {
+		http.Error(w, "Invalid rate override id.", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store.Rates().DeleteOverride(uint(overrideID)); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		http.Error(w, "Failed to delete rate override.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,12 +2,35 @@ package controllers
 
 import (
 	"encoding/json"
-	"hotel_management_system/database"
-	"hotel_management_system/models"
+	"errors"
+	service "hotel_management_system/services"
 	"net/http"
 	"time"
 )
 
+// revenueStatuses are the reservation statuses that count towards revenue.
+var revenueStatuses = []string{"confirmed", "checked-in", "checked-out"}
+
+// revenueBreakdown reports a revenue figure in major units of the base
+// currency, taxes included and excluded.
+func revenueBreakdown(revenue service.Revenue) map[string]float64 {
+	base := service.BaseCurrency()
+	return map[string]float64{
+		"total": service.ToMajorUnits(revenue.Gross, base),
+		"net":   service.ToMajorUnits(revenue.Net(), base),
+		"tax":   service.ToMajorUnits(revenue.Tax, base),
+	}
+}
+
+// writeRevenueError maps a revenue report failure onto an HTTP response.
+func writeRevenueError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, service.ErrNoExchangeRate) {
+		http.Error(w, "Cannot convert to the base currency: "+err.Error()+".", http.StatusUnprocessableEntity)
+		return
+	}
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
 type OccupancyInput struct {
 	StartDate time.Time `json:"start_date"`
 	EndDate   time.Time `json:"end_date"`
@@ -24,7 +47,7 @@ type OccupancyInput struct {
 // @Failure 400 {string} string "Invalid input"
 // @Failure 500 {string} string "Internal server error"
 // @Router /occupancy [post]
-func Occupancy(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Occupancy(w http.ResponseWriter, r *http.Request) {
 
 	type Input struct {
 		StartDate time.Time `json:"start_date"`
@@ -38,22 +61,17 @@ func Occupancy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var reservations []models.Reservation
-	if result := database.DB.Where("start_date < ? AND end_date > ?", input.EndDate, input.StartDate).Find(&reservations); result.Error != nil {
+	//Determining the number of occupied rooms
+	occupiedRooms, err := service.OccupiedRooms(h.store, input.StartDate, input.EndDate)
+	if err != nil {
 		http.Error(w, "Failed to fetch reservations.", http.StatusInternalServerError)
 		return
 	}
 
-	//Determining the number of occupied rooms
-	occupiedRooms := make(map[uint]bool)
-	for _, reservation := range reservations {
-		occupiedRooms[reservation.RoomID] = true
-	}
-
 	//Determining the total number of rooms
-	var totalRooms int64
-	if result := database.DB.Model(&models.Room{}).Count(&totalRooms); result.Error != nil {
-		http.Error(w, "Failed to count rooms."+result.Error.Error(), http.StatusInternalServerError)
+	totalRooms, err := h.store.Rooms().Count()
+	if err != nil {
+		http.Error(w, "Failed to count rooms."+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -73,16 +91,17 @@ func Occupancy(w http.ResponseWriter, r *http.Request) {
 
 // GetTotalRevenue godoc
 // @Summary Get total revenue for a date range
-// @Description Get the total revenue of the hotel for a given date range
+// @Description Get the total revenue of the hotel for a given date range, from the amounts stored on the reservations converted to the base currency at the rates of their booking dates, as total, net and tax
 // @Tags Statistics
 // @Accept  json
 // @Produce  json
-// @Param   input  body  RevenueInput  true  "Date range for revenue calculation"
+// @Param   input  body  controllers.GetTotalRevenue.RevenueInput  true  "Date range for revenue calculation"
 // @Success 200 {object} map[string]float64
 // @Failure 400 {string} string "Invalid input"
+// @Failure 422 {string} string "Missing exchange rate"
 // @Failure 500 {string} string "Internal server error"
 // @Router /revenue/total [post]
-func GetTotalRevenue(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetTotalRevenue(w http.ResponseWriter, r *http.Request) {
 	type RevenueInput struct {
 		StartDate time.Time `json:"start_date"`
 		EndDate   time.Time `json:"end_date"`
@@ -95,36 +114,29 @@ func GetTotalRevenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var totalRevenue float64
-	if result := database.DB.Model(&models.Reservation{}).
-		Select("sum(rooms.price) as revenue").
-		Joins("left join rooms on reservations.room_id = rooms.id").
-		Where("reservations.start_date >= ? AND reservations.end_date <= ? AND reservations.status IN ?", input.StartDate, input.EndDate, []string{"confirmed", "checked-in", "checked-out"}).
-		Scan(&totalRevenue); result.Error != nil {
-		http.Error(w, "Failed to calculate total revenue.", http.StatusInternalServerError)
+	totalRevenue, err := service.TotalRevenue(h.store, input.StartDate, input.EndDate, revenueStatuses)
+	if err != nil {
+		writeRevenueError(w, err, "Failed to calculate total revenue.")
 		return
 	}
 
-	result := map[string]float64{
-		"total_revenue": totalRevenue,
-	}
-
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(result)
+	json.NewEncoder(w).Encode(revenueBreakdown(totalRevenue))
 }
 
 // GetDailyRevenue godoc
 // @Summary Get daily revenue for a date range
-// @Description Get the daily revenue of the hotel for a given date range
+// @Description Get the revenue of the nights sold on each day of a given date range, from the amounts stored on the reservations converted to the base currency at the rates of their booking dates, as total, net and tax
 // @Tags Statistics
 // @Accept  json
 // @Produce  json
-// @Param   input  body  RevenueInput  true  "Date range for revenue calculation"
-// @Success 200 {object} map[string]float64
+// @Param   input  body  controllers.GetDailyRevenue.RevenueInput  true  "Date range for revenue calculation"
+// @Success 200 {object} map[string]map[string]float64
 // @Failure 400 {string} string "Invalid input"
+// @Failure 422 {string} string "Missing exchange rate"
 // @Failure 500 {string} string "Internal server error"
 // @Router /revenue/daily [post]
-func GetDailyRevenue(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetDailyRevenue(w http.ResponseWriter, r *http.Request) {
 	type RevenueInput struct {
 		StartDate time.Time `json:"start_date"`
 		EndDate   time.Time `json:"end_date"`
@@ -137,24 +149,15 @@ func GetDailyRevenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var dailyRevenues []struct {
-		Date    time.Time
-		Revenue float64
-	}
-
-	if result := database.DB.Model(&models.Reservation{}).
-		Select("date(start_date) as date, sum(rooms.price) as revenue").
-		Joins("left join rooms on reservations.room_id = rooms.id").
-		Where("reservations.start_date >= ? AND reservations.end_date <= ? AND reservations.status IN ?", input.StartDate, input.EndDate, []string{"confirmed", "checked-in", "checked-out"}).
-		Group("date(start_date)").
-		Scan(&dailyRevenues); result.Error != nil {
-		http.Error(w, "Failed to calculate daily revenues.", http.StatusInternalServerError)
+	dailyRevenues, err := service.RevenueByDay(h.store, input.StartDate, input.EndDate, revenueStatuses)
+	if err != nil {
+		writeRevenueError(w, err, "Failed to calculate daily revenues.")
 		return
 	}
 
-	result := make(map[string]float64)
+	result := make(map[string]map[string]float64)
 	for _, dailyRevenue := range dailyRevenues {
-		result[dailyRevenue.Date.Format("2006-01-02")] = dailyRevenue.Revenue
+		result[dailyRevenue.Date.Format("2006-01-02")] = revenueBreakdown(dailyRevenue.Revenue)
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -163,16 +166,17 @@ func GetDailyRevenue(w http.ResponseWriter, r *http.Request) {
 
 // GetMonthlyRevenue godoc
 // @Summary Get monthly revenue for a date range
-// @Description Get the monthly revenue of the hotel for a given date range
+// @Description Get the revenue of the nights sold in each month of a given date range, from the amounts stored on the reservations converted to the base currency at the rates of their booking dates, as total, net and tax
 // @Tags Statistics
 // @Accept  json
 // @Produce  json
-// @Param   input  body  RevenueInput  true  "Date range for revenue calculation"
-// @Success 200 {object} map[string]float64
+// @Param   input  body  controllers.GetMonthlyRevenue.RevenueInput  true  "Date range for revenue calculation"
+// @Success 200 {object} map[string]map[string]float64
 // @Failure 400 {string} string "Invalid input"
+// @Failure 422 {string} string "Missing exchange rate"
 // @Failure 500 {string} string "Internal server error"
 // @Router /revenue/monthly [post]
-func GetMonthlyRevenue(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetMonthlyRevenue(w http.ResponseWriter, r *http.Request) {
 	type RevenueInput struct {
 		StartDate time.Time `json:"start_date"`
 		EndDate   time.Time `json:"end_date"`
@@ -185,24 +189,15 @@ func GetMonthlyRevenue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var monthlyRevenues []struct {
-		Month   string
-		Revenue float64
-	}
-
-	if result := database.DB.Model(&models.Reservation{}).
-		Select("DATE_FORMAT(start_date, '%Y-%m') as month, SUM(rooms.price) as revenue").
-		Joins("left join rooms on reservations.room_id = rooms.id").
-		Where("reservations.start_date >= ? AND reservations.end_date <= ? AND reservations.status IN ?", input.StartDate, input.EndDate, []string{"confirmed", "checked-in", "checked-out"}).
-		Group("month").
-		Scan(&monthlyRevenues); result.Error != nil {
-		http.Error(w, "Failed to calculate monthly revenues.", http.StatusInternalServerError)
+	monthlyRevenues, err := service.RevenueByMonth(h.store, input.StartDate, input.EndDate, revenueStatuses)
+	if err != nil {
+		writeRevenueError(w, err, "Failed to calculate monthly revenues.")
 		return
 	}
 
-	result := make(map[string]float64)
+	result := make(map[string]map[string]float64)
 	for _, monthlyRevenue := range monthlyRevenues {
-		result[monthlyRevenue.Month] = monthlyRevenue.Revenue
+		result[monthlyRevenue.Month] = revenueBreakdown(monthlyRevenue.Revenue)
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"net/http"
+	"testing"
+)
+
+// bookReports books room 101 for the nights of January 1 and 2 and confirms
+// it, and room 102 for the same nights, left pending.
+func bookReports(t *testing.T, h *Handler, store repository.Store) {
+	t.Helper()
+	for _, number := range []string{"101", "102"} {
+		input := map[string]interface{}{"room_number": number, "start_date": "2026-01-01T14:00:00Z", "end_date": "2026-01-03T11:00:00Z", "user_id": 1}
+		if w := serve(h.CreateReservation, http.MethodPost, input, nil); w.Code != http.StatusCreated {
+			t.Fatalf("booking room %s: %d %q", number, w.Code, w.Body.String())
+		}
+	}
+	reservation, err := store.Reservations().FindByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reservation.Status = "confirmed"
+	if err := store.Reservations().Save(reservation); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOccupancy(t *testing.T) {
+	h, store := newTestHandler(t)
+	bookReports(t, h, store)
+
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		want  string
+	}{
+		{
+			name:  "during the stays",
+			input: map[string]interface{}{"start_date": "2026-01-02T00:00:00Z", "end_date": "2026-01-03T00:00:00Z"},
+			want:  `{"total_rooms": 2, "occupied_rooms": 2, "available_rooms": 0}`,
+		},
+		{
+			name:  "after departure",
+			input: map[string]interface{}{"start_date": "2026-01-03T12:00:00Z", "end_date": "2026-01-05T00:00:00Z"},
+			want:  `{"total_rooms": 2, "occupied_rooms": 0, "available_rooms": 2}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(h.Occupancy, http.MethodPost, tt.input, nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("got %d %q", w.Code, w.Body.String())
+			}
+			assertJSON(t, w.Body, tt.want)
+		})
+	}
+}
+
+func TestRevenue(t *testing.T) {
+	h, store := newTestHandler(t)
+	bookReports(t, h, store)
+
+	// Only the confirmed stay counts; the pending one is not revenue yet.
+	january := map[string]interface{}{"start_date": "2026-01-01T00:00:00Z", "end_date": "2026-01-31T00:00:00Z"}
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		input   map[string]interface{}
+		want    string
+	}{
+		{
+			name:    "total",
+			handler: h.GetTotalRevenue,
+			input:   january,
+			want:    `{"total": 200, "net": 200, "tax": 0}`,
+		},
+		{
+			name:    "daily",
+			handler: h.GetDailyRevenue,
+			input:   january,
+			want:    `{"2026-01-01": {"total": 100, "net": 100, "tax": 0}, "2026-01-02": {"total": 100, "net": 100, "tax": 0}}`,
+		},
+		{
+			name:    "monthly",
+			handler: h.GetMonthlyRevenue,
+			input:   january,
+			want:    `{"2026-01": {"total": 200, "net": 200, "tax": 0}}`,
+		},
+		{
+			name:    "range missing the stay",
+			handler: h.GetTotalRevenue,
+			input:   map[string]interface{}{"start_date": "2026-02-01T00:00:00Z", "end_date": "2026-02-28T00:00:00Z"},
+			want:    `{"total": 0, "net": 0, "tax": 0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.handler, http.MethodPost, tt.input, nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("got %d %q", w.Code, w.Body.String())
+			}
+			assertJSON(t, w.Body, tt.want)
+		})
+	}
+}
+
+func TestRevenueSplitsTax(t *testing.T) {
+	h, store := newTestHandler(t)
+	for _, tax := range []models.Tax{
+		{Code: "vat", Name: "VAT", Kind: "percentage", Rate: 10, Active: true},
+		{Code: "city", Name: "City tax", Kind: "per-night", Amount: 200, Active: true},
+	} {
+		if err := store.Taxes().Create(&tax); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bookReports(t, h, store)
+
+	// Each night sells for 100 plus 10 of VAT and 2 of city tax.
+	january := map[string]interface{}{"start_date": "2026-01-01T00:00:00Z", "end_date": "2026-01-31T00:00:00Z"}
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string
+	}{
+		{
+			name:    "total",
+			handler: h.GetTotalRevenue,
+			want:    `{"total": 224, "net": 200, "tax": 24}`,
+		},
+		{
+			name:    "daily",
+			handler: h.GetDailyRevenue,
+			want:    `{"2026-01-01": {"total": 112, "net": 100, "tax": 12}, "2026-01-02": {"total": 112, "net": 100, "tax": 12}}`,
+		},
+		{
+			name:    "monthly",
+			handler: h.GetMonthlyRevenue,
+			want:    `{"2026-01": {"total": 224, "net": 200, "tax": 24}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.handler, http.MethodPost, january, nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("got %d %q", w.Code, w.Body.String())
+			}
+			assertJSON(t, w.Body, tt.want)
+		})
+	}
+}
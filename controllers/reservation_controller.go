@@ -2,10 +2,13 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"hotel_management_system/database"
+	"hotel_management_system/middleware"
 	"hotel_management_system/models"
+	"hotel_management_system/repository"
 	service "hotel_management_system/services"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
@@ -16,16 +19,19 @@ import (
 
 // CreateReservation godoc
 // @Summary Create a new reservation
-// @Description Create a new reservation for a room
+// @Description Create a new reservation for a room, given by room_number, or for any free room of a room type, given by its name in room_type. A room of the type is picked by the configured room assignment strategy, honoring the comma separated preferences (high-floor or low-floor, accessible) where it can. The stay is priced night by night as the room type for adults (default 1) and children (default 0) under the requested rate_plan (default "standard") and the breakdown is stored on the reservation. The party must fit within the maximum occupancy of the room type; guests beyond its base occupancy are charged the extra-person surcharge. When the plan requires a deposit it is charged to payment_source.
 // @Tags Reservation
 // @Accept  json
 // @Produce  json
 // @Param   reservation  body models.Reservation  true  "Reservation data"
 // @Success 201 {object} models.Reservation
 // @Failure 400 {string} string "Invalid input"
+// @Failure 402 {string} string "Deposit declined or missing payment source"
+// @Failure 404 {string} string "Room, room type or user not found"
+// @Failure 409 {string} string "Reservation dates conflict or no room of the type available"
 // @Failure 500 {string} string "Internal server error"
 // @Router /reservations [post]
-func CreateReservation(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateReservation(w http.ResponseWriter, r *http.Request) {
 	var input map[string]interface{}
 	err := json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
@@ -33,14 +39,23 @@ func CreateReservation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	roomNumber, ok := input["room_number"].(string)
-	if !ok {
-		http.Error(w, "Invalid room number", http.StatusBadRequest)
-		return
-	}
-	var room models.Room
-	if result := database.DB.Where("number = ?", roomNumber).First(&room); result.Error != nil {
-		http.Error(w, "Room not found.", http.StatusNotFound)
+	var roomID, roomTypeID uint
+	if roomNumber, ok := input["room_number"].(string); ok {
+		room, err := h.store.Rooms().FindByNumber(roomNumber)
+		if err != nil {
+			http.Error(w, "Room not found.", http.StatusNotFound)
+			return
+		}
+		roomID = room.ID
+	} else if typeName, ok := input["room_type"].(string); ok {
+		roomType, err := h.store.RoomTypes().FindByName(typeName)
+		if err != nil {
+			http.Error(w, "Room type not found.", http.StatusNotFound)
+			return
+		}
+		roomTypeID = roomType.ID
+	} else {
+		http.Error(w, "Invalid room number or room type", http.StatusBadRequest)
 		return
 	}
 
@@ -70,36 +85,90 @@ func CreateReservation(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid user ID", http.StatusBadRequest)
 		return
 	}
+	user, err := h.store.Users().FindByID(uint(userID))
+	if err != nil {
+		http.Error(w, "User not found.", http.StatusNotFound)
+		return
+	}
+
+	adults, children := 1.0, 0.0
+	if value, present := input["adults"]; present {
+		if adults, ok = value.(float64); !ok || adults != float64(int(adults)) {
+			http.Error(w, "Invalid number of adults", http.StatusBadRequest)
+			return
+		}
+	}
+	if value, present := input["children"]; present {
+		if children, ok = value.(float64); !ok || children != float64(int(children)) {
+			http.Error(w, "Invalid number of children", http.StatusBadRequest)
+			return
+		}
+	}
+
+	preferences, _ := input["preferences"].(string)
+	preferences, err = service.NormalizePreferences(preferences)
+	if err != nil {
+		writeReservationError(w, err, "Failed to create reservation.")
+		return
+	}
+
+	ratePlanCode, _ := input["rate_plan"].(string)
+	plan, err := service.FindRatePlan(h.store, ratePlanCode)
+	if err != nil {
+		writeReservationError(w, err, "Failed to create reservation.")
+		return
+	}
 
 	reservation := models.Reservation{
-		UserID:    uint(userID),
-		RoomID:    room.ID,
-		StartDate: startDate,
-		EndDate:   endDate,
-		Status:    "pending",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	// Check for conflicting reservations
-	var existingReservations []models.Reservation
-	database.DB.Where("room_id = ? AND start_date < ? AND end_date > ?", room.ID, endDate, startDate).Find(&existingReservations)
-	if len(existingReservations) > 0 {
-		http.Error(w, "Reservation dates conflict with an existing reservation", http.StatusConflict)
+		UserID:      uint(userID),
+		RoomID:      roomID,
+		RoomTypeID:  roomTypeID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Status:      "pending",
+		Adults:      int(adults),
+		Children:    int(children),
+		Preferences: preferences,
+		CreatedAt:   time.Now(),
+	}
+	claims := r.Context().Value("user").(*models.Claims)
+	service.StampStatus(&reservation, claims.UserID)
+
+	if err := service.PriceReservation(h.store, &reservation, plan); err != nil {
+		writeReservationError(w, err, "Failed to price reservation.")
 		return
 	}
 
-	if result := database.DB.Create(&reservation); result.Error != nil {
-		http.Error(w, "Failed to create reservation.", http.StatusInternalServerError)
+	paymentSource, _ := input["payment_source"].(string)
+	deposit, err := service.ChargeDeposit(h.payments, plan, &reservation, paymentSource)
+	if err != nil {
+		writePaymentError(w, err)
 		return
 	}
 
-	var user models.User
-	if result := database.DB.First(&user, reservation.UserID); result.Error != nil {
-		http.Error(w, "User not found.", http.StatusNotFound)
+	if reservation.RoomID != 0 {
+		err = service.SaveReservation(h.store, &reservation, claims.UserID)
+	} else {
+		err = service.BookRoomType(h.store, h.assignment, &reservation, claims.UserID)
+	}
+	if err != nil {
+		if deposit != nil {
+			if err := service.VoidPayment(h.payments, deposit); err != nil {
+				log.Printf("Failed to void deposit %s: %v", deposit.Reference, err)
+			}
+		}
+		writeReservationError(w, err, "Failed to create reservation.")
 		return
 	}
 
+	if deposit != nil {
+		if err := service.RecordPayment(h.store, reservation.ID, deposit, claims.UserID); err != nil {
+			log.Printf("Failed to record deposit %s: %v", deposit.Reference, err)
+			http.Error(w, "Failed to record deposit.", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	go func() {
 		err := service.SendEmail(user.Email, "Reservation Confirmation", "Your reservation has been pending.")
 		if err != nil {
@@ -113,18 +182,22 @@ func CreateReservation(w http.ResponseWriter, r *http.Request) {
 
 // UpdateReservation godoc
 // @Summary Update an existing reservation
-// @Description Update an existing reservation
+// @Description Update the room, dates, status, party or preferences of an existing reservation; the other fields of the reservation are read-only and ignored. Moving it to another room takes it out of the nightly room assignment optimization. The If-Match header must carry the ETag of the reservation as last read.
 // @Tags Reservation
 // @Accept  json
 // @Produce  json
 // @Param   reservation_id  path int  true  "Reservation ID"
-// @Param   reservation  body models.Reservation  true  "Updated reservation data"
+// @Param   If-Match  header string  true  "ETag of the reservation"
+// @Param   reservation  body reservationUpdate  true  "Updated reservation data"
 // @Success 200 {object} models.Reservation
 // @Failure 400 {string} string "Invalid input"
 // @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Reservation dates conflict"
+// @Failure 412 {string} string "Reservation changed since it was read, also by the nightly room assignment optimization"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /reservations/{reservation_id} [put]
-func UpdateReservation(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateReservation(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	reservID, err := strconv.Atoi(params["reservation_id"])
 	if err != nil {
@@ -132,39 +205,176 @@ func UpdateReservation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var reservation models.Reservation
-	if result := database.DB.First(&reservation, reservID); result.Error != nil {
-		http.Error(w, "Reservation not found.", http.StatusNoContent)
+	reservation, err := h.store.Reservations().FindByID(uint(reservID))
+	if err != nil {
+		http.Error(w, "Reservation not found.", http.StatusNotFound)
 		return
 	}
 
-	err = json.NewDecoder(r.Body).Decode(&reservation)
-	if err != nil {
-		http.Error(w, "Invalid input.", http.StatusInternalServerError)
+	var input reservationUpdate
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
 		return
 	}
 
+	previousStatus := reservation.Status
+	previousRoomID, autoAssigned := reservation.RoomID, reservation.AutoAssigned
+	input.apply(reservation)
+	reservation.Version = middleware.ExpectedVersion(r)
+	// A room picked by hand stays picked.
+	reservation.AutoAssigned = autoAssigned && reservation.RoomID == previousRoomID
+	if reservation.Preferences, err = service.NormalizePreferences(reservation.Preferences); err != nil {
+		writeReservationError(w, err, "Failed to update reservation")
+		return
+	}
+
+	claims := r.Context().Value("user").(*models.Claims)
 	reservation.UpdatedAt = time.Now()
+	if reservation.Status != previousStatus {
+		if reservation.Status == "checked-in" || reservation.Status == "checked-out" || reservation.Status == "cancelled" {
+			writeReservationError(w, service.ErrStatusRequiresWorkflow, "Failed to update reservation")
+			return
+		}
+		if err := service.ValidateTransition(previousStatus, reservation.Status); err != nil {
+			writeReservationError(w, err, "Failed to update reservation")
+			return
+		}
+		service.StampStatus(reservation, claims.UserID)
+	}
 
-	if result := database.DB.Save(&reservation); result.Error != nil {
-		http.Error(w, "Failed to update reservation", http.StatusInternalServerError)
+	if err := service.SaveReservation(h.store, reservation, claims.UserID); err != nil {
+		writeReservationError(w, err, "Failed to update reservation")
 		return
 	}
 
+	setETag(w, reservation.Version)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Reservation updated successfully."})
 }
 
+// reservationUpdate holds the fields UpdateReservation takes from the body,
+// under the names a models.Reservation is read with. Fields left out keep
+// their value.
+type reservationUpdate struct {
+	RoomID      *uint      `json:"room_id"`
+	StartDate   *time.Time `json:"StartDate"`
+	EndDate     *time.Time `json:"EndDate"`
+	Status      *string    `json:"status"`
+	Adults      *int       `json:"adults"`
+	Children    *int       `json:"children"`
+	Preferences *string    `json:"preferences"`
+}
+
+func (u reservationUpdate) apply(reservation *models.Reservation) {
+	if u.RoomID != nil {
+		reservation.RoomID = *u.RoomID
+	}
+	if u.StartDate != nil {
+		reservation.StartDate = *u.StartDate
+	}
+	if u.EndDate != nil {
+		reservation.EndDate = *u.EndDate
+	}
+	if u.Status != nil {
+		reservation.Status = *u.Status
+	}
+	if u.Adults != nil {
+		reservation.Adults = *u.Adults
+	}
+	if u.Children != nil {
+		reservation.Children = *u.Children
+	}
+	if u.Preferences != nil {
+		reservation.Preferences = *u.Preferences
+	}
+}
+
+// PatchReservation godoc
+// @Summary Change some fields of a reservation
+// @Description Change the start_date, end_date, adults, children or preferences of a pending, confirmed or checked-in reservation; any other field is refused. New dates are checked against the availability of the room and the stay is priced again; the folio of a checked-in stay is adjusted to the new price. The If-Match header must carry the ETag of the reservation as last read.
+// @Tags Reservation
+// @Accept  json
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Param   If-Match  header string  true  "ETag of the reservation"
+// @Param   patch  body service.ReservationPatch  true  "Fields to change"
+// @Success 200 {object} models.Reservation
+// @Failure 400 {string} string "Invalid input or field not editable"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Reservation dates conflict or reservation not editable"
+// @Failure 412 {string} string "Reservation changed since it was read, also by the nightly room assignment optimization"
+// @Failure 428 {string} string "If-Match header required"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id} [patch]
+func (h *Handler) PatchReservation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.store.Reservations().FindByID(uint(reservID)); err != nil {
+		http.Error(w, "Reservation not found.", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	for field := range fields {
+		if !patchableReservationFields[field] {
+			http.Error(w, fmt.Sprintf("Field %q cannot be changed.", field), http.StatusBadRequest)
+			return
+		}
+	}
+	var patch service.ReservationPatch
+	if err := json.Unmarshal(body, &patch); err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+
+	claims := r.Context().Value("user").(*models.Claims)
+	reservation, err := service.PatchReservation(h.store, uint(reservID), middleware.ExpectedVersion(r), patch, claims.UserID)
+	if err != nil {
+		writeReservationError(w, err, "Failed to update reservation")
+		return
+	}
+
+	setETag(w, reservation.Version)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// patchableReservationFields are the fields PatchReservation accepts.
+var patchableReservationFields = map[string]bool{
+	"start_date":  true,
+	"end_date":    true,
+	"adults":      true,
+	"children":    true,
+	"preferences": true,
+}
+
 // DeleteReservation godoc
 // @Summary Delete a reservation
-// @Description Delete a reservation by ID
+// @Description Delete a reservation by ID, which frees its room. It stays in the revenue reports and can be restored until it is purged.
 // @Tags Reservation
 // @Param   reservation_id  path int  true  "Reservation ID"
+// @Param   If-Match  header string  true  "ETag of the reservation"
 // @Success 204 {string} string "No Content"
 // @Failure 404 {string} string "Reservation not found"
+// @Failure 412 {string} string "Reservation changed since it was read, also by the nightly room assignment optimization"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /reservations/{reservation_id} [delete]
-func DeleteReservation(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) DeleteReservation(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	reservID, err := strconv.Atoi(params["reservation_id"])
 	if err != nil {
@@ -172,14 +382,14 @@ func DeleteReservation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var reservation models.Reservation
-	if result := database.DB.First(&reservation, reservID); result.Error != nil {
-		http.Error(w, "Reservation not found.", http.StatusNoContent)
+	reservation, err := h.store.Reservations().FindByID(uint(reservID))
+	if err != nil {
+		http.Error(w, "Reservation not found.", http.StatusNotFound)
 		return
 	}
 
-	if result := database.DB.Delete(&reservation); result.Error != nil {
-		http.Error(w, "Failed to delete reservation.", http.StatusInternalServerError)
+	if err := h.store.Reservations().Delete(reservation.ID, middleware.ExpectedVersion(r)); err != nil {
+		writeSaveError(w, err, "Failed to delete reservation.")
 		return
 	}
 
@@ -187,6 +397,41 @@ func DeleteReservation(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"message": "Reservation deleted successfully."})
 }
 
+// RestoreReservation godoc
+// @Summary Restore a deleted reservation
+// @Description Bring back a deleted reservation that has not been purged yet. A pending, confirmed or checked-in reservation needs its room to still exist and be free.
+// @Tags Reservation
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Success 200 {object} models.Reservation
+// @Failure 400 {string} string "Invalid reservation id"
+// @Failure 404 {string} string "Deleted reservation not found"
+// @Failure 409 {string} string "Room was deleted or booked again"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/restore [post]
+func (h *Handler) RestoreReservation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	reservation, err := service.RestoreReservation(h.store, uint(reservID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, "Deleted reservation not found.", http.StatusNotFound)
+			return
+		}
+		writeReservationError(w, err, "Failed to restore reservation")
+		return
+	}
+
+	setETag(w, reservation.Version)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservation)
+}
+
 // GetReservations godoc
 // @Summary Get all reservations
 // @Description Get a list of all reservations
@@ -196,9 +441,9 @@ func DeleteReservation(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {string} string "Reservations not found"
 // @Failure 500 {string} string "Internal server error"
 // @Router /reservations [get]
-func GetReservations(w http.ResponseWriter, r *http.Request) {
-	var reservations []models.Reservation
-	if result := database.DB.Find(&reservations); result.Error != nil {
+func (h *Handler) GetReservations(w http.ResponseWriter, r *http.Request) {
+	reservations, err := h.store.Reservations().FindAll()
+	if err != nil {
 		http.Error(w, "Reservations not found.", http.StatusNotFound)
 		return
 	}
@@ -209,7 +454,7 @@ func GetReservations(w http.ResponseWriter, r *http.Request) {
 
 // GetReservationDetails godoc
 // @Summary Get reservation details
-// @Description Get details of a specific reservation
+// @Description Get details of a specific reservation. The ETag header carries its version for conditional updates.
 // @Tags Reservation
 // @Produce  json
 // @Param   reservation_id  path int  true  "Reservation ID"
@@ -218,7 +463,7 @@ func GetReservations(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {string} string "Reservation not found"
 // @Failure 500 {string} string "Internal server error"
 // @Router /reservations/{reservation_id} [get]
-func GetReservationDetails(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetReservationDetails(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	reservID, err := strconv.Atoi(params["reservation_id"])
 	if err != nil {
@@ -226,8 +471,8 @@ func GetReservationDetails(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var reservation models.Reservation
-	if result := database.DB.Find(&reservation, reservID); result.Error != nil {
+	reservation, err := h.store.Reservations().FindByID(uint(reservID))
+	if err != nil {
 		http.Error(w, "Reservation not found.", http.StatusNotFound)
 		return
 	}
@@ -238,18 +483,22 @@ func GetReservationDetails(w http.ResponseWriter, r *http.Request) {
 
 // UpdateReservationStatus godoc
 // @Summary Update reservation status
-// @Description Update the status of a reservation
+// @Description Move a reservation through its lifecycle: pending -> confirmed -> checked-in -> checked-out, with cancelled and no-show allowed from pending or confirmed
 // @Tags Reservation
 // @Accept  json
 // @Produce  json
 // @Param   reservation_id  path int  true  "Reservation ID"
+// @Param   If-Match  header string  true  "ETag of the reservation"
 // @Param   status  body string  true  "New status"
 // @Success 200 {object} models.Reservation
 // @Failure 400 {string} string "Invalid input"
-// @Failure 404 {string} string "Reservation not found"
+// @Failure 404 {string} string "Reservation or user not found"
+// @Failure 409 {string} string "Status transition not allowed"
+// @Failure 412 {string} string "Reservation changed since it was read, also by the nightly room assignment optimization"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /reservations/{reservation_id}/status [put]
-func UpdateReservationStatus(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateReservationStatus(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	reservID, err := strconv.Atoi(params["reservation_id"])
 	if err != nil {
@@ -266,39 +515,25 @@ func UpdateReservationStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var reservation models.Reservation
-	if result := database.DB.First(&reservation, reservID); result.Error != nil {
+	current, err := h.store.Reservations().FindByID(uint(reservID))
+	if err != nil {
 		http.Error(w, "Reservation not found", http.StatusNotFound)
 		return
 	}
 
-	validStatuses := []string{"pending", "confirmed", "checked-in", "checked-out", "cancelled", "no-show"}
-	isValidStatus := false
-	for _, status := range validStatuses {
-		if input.Status == status {
-			isValidStatus = true
-			break
-		}
-	}
-
-	if !isValidStatus {
-		http.Error(w, "Invalid status value", http.StatusBadRequest)
-		return
-	}
-
-	reservation.Status = input.Status
-	reservation.UpdatedAt = time.Now()
-
-	if result := database.DB.Save(&reservation); result.Error != nil {
-		http.Error(w, "Failed to update reservation", http.StatusInternalServerError)
+	user, err := h.store.Users().FindByID(current.UserID)
+	if err != nil {
+		http.Error(w, "User not found.", http.StatusNotFound)
 		return
 	}
 
-	var user models.User
-	if result := database.DB.First(&user, reservation.UserID); result.Error != nil {
-		http.Error(w, "User not found.", http.StatusNotFound)
+	claims := r.Context().Value("user").(*models.Claims)
+	reservation, err := service.TransitionReservation(h.store, uint(reservID), middleware.ExpectedVersion(r), input.Status, claims.UserID)
+	if err != nil {
+		writeReservationError(w, err, "Failed to update reservation")
 		return
 	}
+	h.refundIfCancelled(reservation, claims.UserID)
 
 	go func() {
 		message := fmt.Sprintf("Your reservation status: %s", reservation.Status)
@@ -308,6 +543,114 @@ func UpdateReservationStatus(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	setETag(w, reservation.Version)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(reservation)
 }
+
+// refundIfCancelled refunds what a reservation that was just cancelled paid
+// beyond its cancellation penalty. The cancellation stands even if a refund
+// fails; failed refunds are recorded with the payments and logged.
+func (h *Handler) refundIfCancelled(reservation *models.Reservation, actorID uint) {
+	if reservation.Status != "cancelled" {
+		return
+	}
+	if _, err := service.RefundCancelled(h.store, h.payments, reservation.ID, actorID); err != nil {
+		log.Printf("Failed to refund reservation %d: %v", reservation.ID, err)
+	}
+}
+
+// writeReservationError maps the errors of service.SaveReservation onto an
+// HTTP response, falling back to a 500 with the given message.
+func writeReservationError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrUnknownRatePlan):
+		http.Error(w, "Unknown rate plan.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidDateRange):
+		http.Error(w, "End date must be after start date.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrRoomUnavailable):
+		http.Error(w, "Reservation dates conflict with an existing reservation", http.StatusConflict)
+	case errors.Is(err, service.ErrRoomOutOfService):
+		http.Error(w, "Room is out of service.", http.StatusConflict)
+	case errors.Is(err, service.ErrNoRoomAvailable):
+		http.Error(w, "No room of the type is available for the requested dates.", http.StatusConflict)
+	case errors.Is(err, service.ErrUnknownRoomType):
+		http.Error(w, "Unknown room type.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrMoveNotAllowed):
+		http.Error(w, "Only upcoming and checked-in reservations can move rooms before departure.", http.StatusConflict)
+	case errors.Is(err, service.ErrSameRoom):
+		http.Error(w, "Reservation is already in that room.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrStaySplit):
+		http.Error(w, "The guest has moved rooms during the stay; use the move endpoint to change the room.", http.StatusConflict)
+	case errors.Is(err, repository.ErrVersionConflict):
+		writeVersionConflict(w)
+	case errors.Is(err, service.ErrUserNotFound):
+		http.Error(w, "User not found.", http.StatusNotFound)
+	case errors.Is(err, service.ErrRoomDeleted):
+		http.Error(w, "The room of the reservation was deleted.", http.StatusConflict)
+	case errors.Is(err, service.ErrNotEditable):
+		http.Error(w, "Only pending, confirmed and checked-in reservations can be changed.", http.StatusConflict)
+	case errors.Is(err, service.ErrArrivalFixed):
+		http.Error(w, "The arrival of a checked-in reservation cannot change.", http.StatusConflict)
+	case errors.Is(err, service.ErrInvalidPreferences):
+		http.Error(w, "Unknown or conflicting room preferences.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidStatus):
+		http.Error(w, "Invalid status value", http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidTransition):
+		http.Error(w, "Reservation status transition not allowed", http.StatusConflict)
+	case errors.Is(err, service.ErrStatusRequiresWorkflow):
+		http.Error(w, "Use the check-in, check-out and cancel endpoints to change this status", http.StatusConflict)
+	case errors.Is(err, service.ErrOutsideStayWindow):
+		http.Error(w, "Reservation can only be checked in during its stay dates", http.StatusConflict)
+	case errors.Is(err, service.ErrRoomNotReady):
+		http.Error(w, "Room is not ready for check-in", http.StatusConflict)
+	case errors.Is(err, service.ErrOutstandingBalance):
+		http.Error(w, "Folio balance must be settled before check-out", http.StatusConflict)
+	case errors.Is(err, service.ErrInvalidGuests):
+		http.Error(w, "A reservation needs at least one adult and no negative guest counts.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrOverOccupancy):
+		http.Error(w, "Too many guests for the room type.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrNoExchangeRate):
+		http.Error(w, "Cannot price the stay: "+err.Error()+".", http.StatusUnprocessableEntity)
+	case errors.Is(err, service.ErrRoomNotFound):
+		http.Error(w, "Room not found.", http.StatusNotFound)
+	case errors.Is(err, repository.ErrNotFound):
+		http.Error(w, "Reservation not found.", http.StatusNotFound)
+	default:
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
+
+// GetReservationHistory godoc
+// @Summary Get reservation history
+// @Description Get every status change and field edit of a reservation with the acting user, oldest first
+// @Tags Reservation
+// @Produce  json
+// @Param   reservation_id  path int  true  "Reservation ID"
+// @Success 200 {array} models.ReservationEvent
+// @Failure 400 {string} string "Invalid reservation ID"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reservations/{reservation_id}/history [get]
+func (h *Handler) GetReservationHistory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	reservID, err := strconv.Atoi(params["reservation_id"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id.", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.store.Reservations().FindByID(uint(reservID)); err != nil {
+		http.Error(w, "Reservation not found.", http.StatusNotFound)
+		return
+	}
+
+	events, err := h.store.ReservationEvents().FindByReservation(uint(reservID))
+	if err != nil {
+		http.Error(w, "Failed to fetch reservation history.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(events)
+}
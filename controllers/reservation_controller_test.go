@@ -0,0 +1,333 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"hotel_management_system/middleware"
+	"hotel_management_system/models"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCreateReservation(t *testing.T) {
+	h, _ := newTestHandler(t)
+
+	// The cases run in order against the same store, so later ones see the
+	// bookings of earlier ones.
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		want  int
+	}{
+		{
+			name:  "free room",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-01-01T14:00:00Z", "end_date": "2026-01-03T11:00:00Z", "user_id": 1},
+			want:  http.StatusCreated,
+		},
+		{
+			name:  "overlapping stay",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-01-02T14:00:00Z", "end_date": "2026-01-04T11:00:00Z", "user_id": 1},
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "same nights in another room",
+			input: map[string]interface{}{"room_number": "102", "start_date": "2026-01-02T14:00:00Z", "end_date": "2026-01-04T11:00:00Z", "user_id": 1},
+			want:  http.StatusCreated,
+		},
+		{
+			name:  "arrival on the day of departure",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-01-03T14:00:00Z", "end_date": "2026-01-05T11:00:00Z", "user_id": 1},
+			want:  http.StatusCreated,
+		},
+		{
+			name:  "end before start",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-02-05T14:00:00Z", "end_date": "2026-02-04T11:00:00Z", "user_id": 1},
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "unknown room",
+			input: map[string]interface{}{"room_number": "999", "start_date": "2026-02-01T14:00:00Z", "end_date": "2026-02-03T11:00:00Z", "user_id": 1},
+			want:  http.StatusNotFound,
+		},
+		{
+			name:  "unknown rate plan",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-02-01T14:00:00Z", "end_date": "2026-02-03T11:00:00Z", "user_id": 1, "rate_plan": "nope"},
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "party within the room occupancy",
+			input: map[string]interface{}{"room_number": "102", "start_date": "2026-02-01T14:00:00Z", "end_date": "2026-02-03T11:00:00Z", "user_id": 1, "adults": 1, "children": 1},
+			want:  http.StatusCreated,
+		},
+		{
+			name:  "too many guests",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-02-01T14:00:00Z", "end_date": "2026-02-03T11:00:00Z", "user_id": 1, "adults": 2, "children": 1},
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "no adult",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-02-01T14:00:00Z", "end_date": "2026-02-03T11:00:00Z", "user_id": 1, "adults": 0, "children": 1},
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "fractional guest count",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-02-01T14:00:00Z", "end_date": "2026-02-03T11:00:00Z", "user_id": 1, "adults": 1.5},
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "deposit without payment source",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-03-01T14:00:00Z", "end_date": "2026-03-03T11:00:00Z", "user_id": 1, "rate_plan": "non-refundable"},
+			want:  http.StatusPaymentRequired,
+		},
+		{
+			name:  "declined deposit",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-03-01T14:00:00Z", "end_date": "2026-03-03T11:00:00Z", "user_id": 1, "rate_plan": "non-refundable", "payment_source": "tok_declined"},
+			want:  http.StatusPaymentRequired,
+		},
+		{
+			name:  "deposit charged",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-03-01T14:00:00Z", "end_date": "2026-03-03T11:00:00Z", "user_id": 1, "rate_plan": "non-refundable", "payment_source": "tok_visa"},
+			want:  http.StatusCreated,
+		},
+		{
+			name:  "any room of a type",
+			input: map[string]interface{}{"room_type": "double", "start_date": "2026-04-01T14:00:00Z", "end_date": "2026-04-03T11:00:00Z", "user_id": 1},
+			want:  http.StatusCreated,
+		},
+		{
+			name:  "the last room of the type",
+			input: map[string]interface{}{"room_type": "double", "start_date": "2026-04-01T14:00:00Z", "end_date": "2026-04-03T11:00:00Z", "user_id": 1},
+			want:  http.StatusCreated,
+		},
+		{
+			name:  "no room of the type left",
+			input: map[string]interface{}{"room_type": "double", "start_date": "2026-04-02T14:00:00Z", "end_date": "2026-04-03T11:00:00Z", "user_id": 1},
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "unknown room type",
+			input: map[string]interface{}{"room_type": "villa", "start_date": "2026-04-01T14:00:00Z", "end_date": "2026-04-03T11:00:00Z", "user_id": 1},
+			want:  http.StatusNotFound,
+		},
+		{
+			name:  "missing user",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-02-01T14:00:00Z", "end_date": "2026-02-03T11:00:00Z"},
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "unknown user",
+			input: map[string]interface{}{"room_number": "101", "start_date": "2026-02-01T14:00:00Z", "end_date": "2026-02-03T11:00:00Z", "user_id": 9},
+			want:  http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(h.CreateReservation, http.MethodPost, tt.input, nil)
+			if w.Code != tt.want {
+				t.Errorf("got %d %q, want %d", w.Code, w.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestCreateReservationConcurrent books the same room for the same nights
+// from several requests at once. The room lock taken by CreateReservation
+// must let exactly one of them through.
+func TestCreateReservationConcurrent(t *testing.T) {
+	h, store := newTestHandler(t)
+
+	const requests = 10
+	input := map[string]interface{}{"room_number": "101", "start_date": "2026-01-01T14:00:00Z", "end_date": "2026-01-03T11:00:00Z", "user_id": 1}
+	codes := make(chan int, requests)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			codes <- serve(h.CreateReservation, http.MethodPost, input, nil).Code
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(codes)
+
+	counts := make(map[int]int)
+	for code := range codes {
+		counts[code]++
+	}
+	if counts[http.StatusCreated] != 1 || counts[http.StatusConflict] != requests-1 {
+		t.Errorf("got status counts %v, want one %d and %d %d", counts, http.StatusCreated, requests-1, http.StatusConflict)
+	}
+
+	reservations, err := store.Reservations().FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reservations) != 1 {
+		t.Errorf("got %d reservations, want 1", len(reservations))
+	}
+}
+
+// TestMoveReservation moves an upcoming stay, far enough ahead to be before
+// arrival whenever the test runs.
+func TestMoveReservation(t *testing.T) {
+	h, _ := newTestHandler(t)
+	input := map[string]interface{}{"room_number": "101", "start_date": "2099-01-01T14:00:00Z", "end_date": "2099-01-03T11:00:00Z", "user_id": 1}
+	if w := serve(h.CreateReservation, http.MethodPost, input, nil); w.Code != http.StatusCreated {
+		t.Fatalf("booking: %d %q", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name  string
+		id    string
+		input map[string]interface{}
+		want  int
+	}{
+		{name: "missing room number", id: "1", input: map[string]interface{}{}, want: http.StatusBadRequest},
+		{name: "unknown room", id: "1", input: map[string]interface{}{"room_number": "999"}, want: http.StatusNotFound},
+		{name: "unknown reservation", id: "99", input: map[string]interface{}{"room_number": "102"}, want: http.StatusNotFound},
+		{name: "same room", id: "1", input: map[string]interface{}{"room_number": "101"}, want: http.StatusBadRequest},
+		{name: "another room", id: "1", input: map[string]interface{}{"room_number": "102"}, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(h.MoveReservation, http.MethodPost, tt.input, map[string]string{"reservation_id": tt.id})
+			if w.Code != tt.want {
+				t.Errorf("got %d %q, want %d", w.Code, w.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestPatchReservation changes a stay in two steps behind the IfMatch
+// middleware, as routed. The cases run in order, so each If-Match names the
+// version the previous change left.
+func TestPatchReservation(t *testing.T) {
+	h, _ := newTestHandler(t)
+	input := map[string]interface{}{"room_number": "101", "start_date": "2026-01-01T14:00:00Z", "end_date": "2026-01-03T11:00:00Z", "user_id": 1}
+	if w := serve(h.CreateReservation, http.MethodPost, input, nil); w.Code != http.StatusCreated {
+		t.Fatalf("booking: %d %q", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		ifMatch string
+		input   interface{}
+		want    int
+		etag    string
+	}{
+		{name: "field not in the whitelist", id: "1", ifMatch: `"1"`, input: map[string]interface{}{"room_id": 2}, want: http.StatusBadRequest},
+		{name: "status cannot be patched", id: "1", ifMatch: `"1"`, input: map[string]interface{}{"status": "checked-out"}, want: http.StatusBadRequest},
+		{name: "not an object", id: "1", ifMatch: `"1"`, input: "checked-out", want: http.StatusBadRequest},
+		{name: "unknown reservation", id: "99", ifMatch: `"1"`, input: map[string]interface{}{"adults": 2}, want: http.StatusNotFound},
+		{name: "missing If-Match", id: "1", input: map[string]interface{}{"adults": 2}, want: http.StatusPreconditionRequired},
+		{name: "stale If-Match", id: "1", ifMatch: `"7"`, input: map[string]interface{}{"adults": 2}, want: http.StatusPreconditionFailed},
+		{name: "longer stay", id: "1", ifMatch: `"1"`, input: map[string]interface{}{"end_date": "2026-01-04T11:00:00Z"}, want: http.StatusOK, etag: `"2"`},
+		{name: "version already used", id: "1", ifMatch: `"1"`, input: map[string]interface{}{"adults": 2}, want: http.StatusPreconditionFailed},
+		{name: "too many guests", id: "1", ifMatch: `"2"`, input: map[string]interface{}{"adults": 2, "children": 1}, want: http.StatusBadRequest},
+		{name: "any version", id: "1", ifMatch: "*", input: map[string]interface{}{"adults": 2, "preferences": "high-floor"}, want: http.StatusOK, etag: `"3"`},
+	}
+	patch := middleware.IfMatch(h.ReservationVersion)(http.HandlerFunc(h.PatchReservation)).ServeHTTP
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := patch
+			if tt.ifMatch != "" {
+				handler = withIfMatch(tt.ifMatch, handler)
+			}
+			w := serve(handler, http.MethodPatch, tt.input, map[string]string{"reservation_id": tt.id})
+			if w.Code != tt.want {
+				t.Fatalf("got %d %q, want %d", w.Code, w.Body.String(), tt.want)
+			}
+			if etag := w.Header().Get("ETag"); etag != tt.etag {
+				t.Errorf("got ETag %s, want %s", etag, tt.etag)
+			}
+		})
+	}
+
+	get := middleware.ETag(h.ReservationVersion)(http.HandlerFunc(h.GetReservationDetails)).ServeHTTP
+	w := serve(get, http.MethodGet, nil, map[string]string{"reservation_id": "1"})
+	var reservation models.Reservation
+	if err := json.Unmarshal(w.Body.Bytes(), &reservation); err != nil {
+		t.Fatal(err)
+	}
+	if w.Header().Get("ETag") != `"3"` || reservation.Adults != 2 || reservation.Preferences != "high-floor" || reservation.TotalAmount != 30000 {
+		t.Errorf("got ETag %s, %d adults preferring %q at %d, want \"3\", 2 preferring high-floor at 30000",
+			w.Header().Get("ETag"), reservation.Adults, reservation.Preferences, reservation.TotalAmount)
+	}
+
+	// Both patches are in the history, with the price the longer stay cost.
+	w = serve(h.GetReservationHistory, http.MethodGet, nil, map[string]string{"reservation_id": "1"})
+	var events []models.ReservationEvent
+	if err := json.Unmarshal(w.Body.Bytes(), &events); err != nil {
+		t.Fatal(err)
+	}
+	var changes []string
+	for _, event := range events {
+		changes = append(changes, event.Field+" "+event.OldValue+">"+event.NewValue)
+	}
+	want := "[status >pending end_date 2026-01-03T11:00:00Z>2026-01-04T11:00:00Z total_amount 20000>30000 adults 1>2 preferences >high-floor]"
+	if fmt.Sprint(changes) != want {
+		t.Errorf("got history %v, want %s", changes, want)
+	}
+}
+
+// TestUpdateReservation sends a whole reservation back with its read-only
+// fields changed: only the party is taken from it.
+func TestUpdateReservation(t *testing.T) {
+	h, store := newTestHandler(t)
+	input := map[string]interface{}{"room_number": "101", "start_date": "2026-01-01T14:00:00Z", "end_date": "2026-01-03T11:00:00Z", "user_id": 1}
+	if w := serve(h.CreateReservation, http.MethodPost, input, nil); w.Code != http.StatusCreated {
+		t.Fatalf("booking: %d %q", w.Code, w.Body.String())
+	}
+
+	update := map[string]interface{}{"adults": 2, "user_id": 9, "total_amount": 1, "rate_plan_id": 9, "currency": "EUR", "version": 7}
+	w := serve(atVersion(1, h.UpdateReservation), http.MethodPut, update, map[string]string{"reservation_id": "1"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d %q, want 200", w.Code, w.Body.String())
+	}
+	stored, err := store.Reservations().FindByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Adults != 2 || stored.UserID != 1 || stored.TotalAmount != 20000 || stored.Currency != "USD" || stored.Version != 2 {
+		t.Errorf("got %+v, want 2 adults and the rest as booked", stored)
+	}
+}
+
+// TestNotFoundMessages checks that a missing reservation and a missing room
+// are told apart.
+func TestNotFoundMessages(t *testing.T) {
+	h, _ := newTestHandler(t)
+	input := map[string]interface{}{"room_number": "101", "start_date": "2099-01-01T14:00:00Z", "end_date": "2099-01-03T11:00:00Z", "user_id": 1}
+	if w := serve(h.CreateReservation, http.MethodPost, input, nil); w.Code != http.StatusCreated {
+		t.Fatalf("booking: %d %q", w.Code, w.Body.String())
+	}
+	patch := middleware.IfMatch(h.ReservationVersion)(http.HandlerFunc(h.PatchReservation)).ServeHTTP
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+		input   interface{}
+		id      string
+		want    string
+	}{
+		{name: "update", handler: atVersion(1, h.UpdateReservation), method: http.MethodPut, input: map[string]interface{}{"adults": 2}, id: "99", want: "Reservation not found."},
+		{name: "patch", handler: withIfMatch(`"1"`, patch), method: http.MethodPatch, input: map[string]interface{}{"adults": 2}, id: "99", want: "Reservation not found."},
+		{name: "cancellation preview", handler: h.PreviewCancellation, method: http.MethodGet, id: "99", want: "Reservation not found"},
+		{name: "move to an unknown room", handler: h.MoveReservation, method: http.MethodPost, input: map[string]interface{}{"room_number": "999"}, id: "1", want: "Room not found."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.handler, tt.method, tt.input, map[string]string{"reservation_id": tt.id})
+			if w.Code != http.StatusNotFound || strings.TrimSpace(w.Body.String()) != tt.want {
+				t.Errorf("got %d %q, want 404 %q", w.Code, w.Body.String(), tt.want)
+			}
+		})
+	}
+}
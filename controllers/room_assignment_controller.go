@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"encoding/json"
+	service "hotel_management_system/services"
+	"net/http"
+)
+
+// OptimizeRoomAssignments godoc
+// @Summary Optimize room assignments
+// @Description Run the nightly room assignment optimization now. Future reservations booked by room type, and not moved by hand since, are shuffled between the rooms of their type so that free nights stay together. Each move raises the version of the reservation, so an ETag read before no longer matches. Returns the reservations moved.
+// @Tags Room Assignment
+// @Produce  json
+// @Success 200 {array} service.RoomMove
+// @Failure 500 {string} string "Internal server error"
+// @Router /room-assignments/optimize [post]
+func (h *Handler) OptimizeRoomAssignments(w http.ResponseWriter, r *http.Request) {
+	moves, err := service.OptimizeRoomAssignments(h.store, h.assignment)
+	if err != nil {
+		http.Error(w, "Failed to optimize room assignments.", http.StatusInternalServerError)
+		return
+	}
+	if moves == nil {
+		moves = []service.RoomMove{}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(moves)
+}
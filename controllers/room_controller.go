@@ -2,8 +2,11 @@ package controllers
 
 import (
 	"encoding/json"
-	"hotel_management_system/database"
+	"errors"
+	"hotel_management_system/middleware"
 	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
 	"net/http"
 	"strconv"
 	"time"
@@ -13,19 +16,21 @@ import (
 
 // CreateRoom godoc
 // @Summary Create a new room
-// @Description Create a new room with number, type, status, and price
+// @Description Create a new room with number, room type and status. Price and capacity come from the room type.
 // @Tags Room
 // @Accept  json
 // @Produce  json
 // @Param   number  body string  true  "Room Number"
-// @Param   type    body string  true  "Room Type"
+// @Param   RoomTypeID body int  true  "Room type ID"
 // @Param   status  body string  true  "Room Status"
-// @Param   price   body float64 true  "Room Price"
+// @Param   Floor  body int  false  "Floor"
+// @Param   Accessible  body bool  false  "Whether the room is accessible"
 // @Success 201 {string} string "Room created successfully"
 // @Failure 400 {string} string "Invalid input"
+// @Failure 409 {string} string "Room number taken by another or a deleted room"
 // @Failure 500 {string} string "Internal server error"
 // @Router /rooms [post]
-func CreateRoom(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 	var room models.Room
 	err := json.NewDecoder(r.Body).Decode(&room)
 	if err != nil {
@@ -33,11 +38,16 @@ func CreateRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, err := service.FindRoomType(h.store, room.RoomTypeID); err != nil {
+		writeRoomTypeError(w, err)
+		return
+	}
+
 	room.CreatedAt = time.Now()
 	room.UpdateAt = time.Now()
 
-	if result := database.DB.Create(&room); result.Error != nil {
-		http.Error(w, "Failed to create room: "+result.Error.Error(), http.StatusInternalServerError)
+	if err := h.store.Rooms().Create(&room); err != nil {
+		writeRoomError(w, err, "Failed to create room: "+err.Error())
 		return
 	}
 
@@ -47,43 +57,60 @@ func CreateRoom(w http.ResponseWriter, r *http.Request) {
 
 // UpdateRoom godoc
 // @Summary Update an existing room
-// @Description Update an existing room with number, type, status, and price
+// @Description Update an existing room with number, room type and status. Price and capacity come from the room type.
 // @Tags Room
 // @Accept  json
 // @Produce  json
 // @Param   room_id  path int  true  "Room ID"
+// @Param   If-Match  header string  true  "ETag of the room"
 // @Param   number  body string  true  "Room Number"
-// @Param   type    body string  true  "Room Type"
+// @Param   RoomTypeID body int  true  "Room type ID"
 // @Param   status  body string  true  "Room Status"
-// @Param   price   body float64 true  "Room Price"
+// @Param   Floor  body int  false  "Floor"
+// @Param   Accessible  body bool  false  "Whether the room is accessible"
 // @Success 200 {string} string "Room updated successfully"
 // @Failure 400 {string} string "Invalid input"
 // @Failure 404 {string} string "Room not found"
+// @Failure 409 {string} string "Room number taken by another or a deleted room"
+// @Failure 412 {string} string "Room changed since it was read"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /rooms/{room_id} [put]
-func UpdateRoom(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
-	roomID := params["room_id"]
+	roomID, err := strconv.Atoi(params["room_id"])
+	if err != nil {
+		http.Error(w, "Invalid room id", http.StatusBadRequest)
+		return
+	}
 
-	var room models.Room
-	if result := database.DB.First(&room, roomID); result.Error != nil {
-		http.Error(w, "Room not found: "+result.Error.Error(), http.StatusNotFound)
+	room, err := h.store.Rooms().FindByID(uint(roomID))
+	if err != nil {
+		http.Error(w, "Room not found: "+err.Error(), http.StatusNotFound)
 		return
 	}
 
-	err := json.NewDecoder(r.Body).Decode(&room)
+	err = json.NewDecoder(r.Body).Decode(room)
 	if err != nil {
 		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	room.ID = uint(roomID)
+	room.Version = middleware.ExpectedVersion(r)
+
+	if _, err := service.FindRoomType(h.store, room.RoomTypeID); err != nil {
+		writeRoomTypeError(w, err)
+		return
+	}
 
 	room.UpdateAt = time.Now()
 
-	if result := database.DB.Save(&room); result.Error != nil {
-		http.Error(w, "Failed to update room "+result.Error.Error(), http.StatusInternalServerError)
+	if err := h.store.Rooms().Save(room); err != nil {
+		writeRoomError(w, err, "Failed to update room "+err.Error())
 		return
 	}
 
+	setETag(w, room.Version)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": ":Room updated succesfully."})
 
@@ -91,14 +118,18 @@ func UpdateRoom(w http.ResponseWriter, r *http.Request) {
 
 // DeleteRoom godoc
 // @Summary Delete a room
-// @Description Delete a room by ID
+// @Description Delete a room by ID. Rooms with current or upcoming reservations cannot be deleted. A deleted room can be restored until it is purged.
 // @Tags Room
 // @Param   room_id  path int  true  "Room ID"
+// @Param   If-Match  header string  true  "ETag of the room"
 // @Success 204 {string} string "No Content"
 // @Failure 404 {string} string "Room not found"
+// @Failure 409 {string} string "Room has current or upcoming reservations"
+// @Failure 412 {string} string "Room changed since it was read"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /rooms/{room_id} [delete]
-func DeleteRoom(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	roomID, err := strconv.Atoi(params["room_id"])
 	if err != nil {
@@ -106,14 +137,17 @@ func DeleteRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var room models.Room
-	if result := database.DB.First(&room, roomID); result.Error != nil {
-		http.Error(w, "Room not found", http.StatusNotFound)
-		return
-	}
-
-	if result := database.DB.Delete(&room); result.Error != nil {
-		http.Error(w, "Failed to delete room: "+result.Error.Error(), http.StatusInternalServerError)
+	if err := service.DeleteRoom(h.store, uint(roomID), middleware.ExpectedVersion(r)); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			http.Error(w, "Room not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrRoomInUse):
+			http.Error(w, "Room has current or upcoming reservations.", http.StatusConflict)
+		case errors.Is(err, repository.ErrVersionConflict):
+			writeVersionConflict(w)
+		default:
+			http.Error(w, "Failed to delete room: "+err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -129,10 +163,10 @@ func DeleteRoom(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {array} models.Room
 // @Failure 500 {string} string "Internal server error"
 // @Router /rooms [get]
-func GetRooms(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetRooms(w http.ResponseWriter, r *http.Request) {
 
-	var rooms []models.Room
-	if result := database.DB.Find(&rooms); result.Error != nil {
+	rooms, err := h.store.Rooms().FindAll()
+	if err != nil {
 		http.Error(w, "Rooms not found.", http.StatusInternalServerError)
 		return
 	}
@@ -143,7 +177,7 @@ func GetRooms(w http.ResponseWriter, r *http.Request) {
 
 // GetRoomDetails godoc
 // @Summary Get room details
-// @Description Get details of a specific room
+// @Description Get details of a specific room. The ETag header carries its version for conditional updates.
 // @Tags Room
 // @Produce  json
 // @Param   room_id  path int  true  "Room ID"
@@ -151,7 +185,7 @@ func GetRooms(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {string} string "Invalid room ID"
 // @Failure 404 {string} string "Room not found"
 // @Router /rooms/{room_id} [get]
-func GetRoomDetails(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetRoomDetails(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	roomID, err := strconv.Atoi(params["room_id"])
 	if err != nil {
@@ -159,8 +193,8 @@ func GetRoomDetails(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var room models.Room
-	if result := database.DB.Find(&room, roomID); result.Error != nil {
+	room, err := h.store.Rooms().FindByID(uint(roomID))
+	if err != nil {
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
@@ -169,3 +203,53 @@ func GetRoomDetails(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(room)
 
 }
+
+// RestoreRoom godoc
+// @Summary Restore a deleted room
+// @Description Bring back a deleted room that has not been purged yet. Its room type must still exist.
+// @Tags Room
+// @Produce  json
+// @Param   room_id  path int  true  "Room ID"
+// @Success 200 {object} models.Room
+// @Failure 400 {string} string "Invalid room id"
+// @Failure 404 {string} string "Deleted room not found"
+// @Failure 409 {string} string "Room type was deleted"
+// @Failure 500 {string} string "Internal server error"
+// @Router /rooms/{room_id}/restore [post]
+func (h *Handler) RestoreRoom(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	roomID, err := strconv.Atoi(params["room_id"])
+	if err != nil {
+		http.Error(w, "Invalid room id", http.StatusBadRequest)
+		return
+	}
+
+	room, err := service.RestoreRoom(h.store, uint(roomID))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			http.Error(w, "Deleted room not found.", http.StatusNotFound)
+		case errors.Is(err, service.ErrUnknownRoomType):
+			http.Error(w, "The room type of the room was deleted.", http.StatusConflict)
+		default:
+			http.Error(w, "Failed to restore room: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	setETag(w, room.Version)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(room)
+}
+
+// writeRoomError maps a room write failure onto an HTTP response.
+func writeRoomError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrDeletedDuplicate):
+		http.Error(w, "Room number belongs to a deleted room; restore it instead.", http.StatusConflict)
+	case errors.Is(err, repository.ErrDuplicate):
+		http.Error(w, "Room number already exists.", http.StatusConflict)
+	default:
+		writeSaveError(w, err, message)
+	}
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCreateRoom(t *testing.T) {
+	h, store := newTestHandler(t)
+	room, err := store.Rooms().FindByNumber("102")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Rooms().Delete(room.ID, room.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		number string
+		want   int
+	}{
+		{name: "new number", number: "103", want: http.StatusCreated},
+		{name: "number of a room", number: "101", want: http.StatusConflict},
+		{name: "number of a deleted room", number: "102", want: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := map[string]interface{}{"number": tt.number, "RoomTypeID": room.RoomTypeID, "status": "available"}
+			w := serve(h.CreateRoom, http.MethodPost, input, nil)
+			if w.Code != tt.want {
+				t.Errorf("got %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+// TestUpdateRoom saves room 101 at the version its If-Match named. The cases
+// run in order: once the first update moved the room to version 2, a save
+// still made against version 1, e.g. one that passed the If-Match check just
+// before, is refused.
+func TestUpdateRoom(t *testing.T) {
+	h, _ := newTestHandler(t)
+	input := map[string]interface{}{"number": "101", "RoomTypeID": 1, "status": "cleaning"}
+
+	tests := []struct {
+		name    string
+		version uint
+		want    int
+		etag    string
+	}{
+		{name: "current version", version: 1, want: http.StatusOK, etag: `"2"`},
+		{name: "changed in between", version: 1, want: http.StatusPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(atVersion(tt.version, h.UpdateRoom), http.MethodPut, input, map[string]string{"room_id": "1"})
+			if w.Code != tt.want || w.Header().Get("ETag") != tt.etag {
+				t.Errorf("got %d with ETag %q, want %d with %q: %s", w.Code, w.Header().Get("ETag"), tt.want, tt.etag, w.Body)
+			}
+		})
+	}
+}
+
+func TestDeleteAndRestoreRoom(t *testing.T) {
+	h, _ := newTestHandler(t)
+	vars := map[string]string{"room_id": "2"}
+
+	if w := serve(atVersion(1, h.DeleteRoom), http.MethodDelete, nil, vars); w.Code != http.StatusNoContent {
+		t.Fatalf("delete: got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(h.GetRoomDetails, http.MethodGet, nil, vars); w.Code != http.StatusNotFound {
+		t.Errorf("deleted room: got %d, want %d", w.Code, http.StatusNotFound)
+	}
+	w := serve(h.RestoreRoom, http.MethodPost, nil, vars)
+	if w.Code != http.StatusOK {
+		t.Fatalf("restore: got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(h.RestoreRoom, http.MethodPost, nil, vars); w.Code != http.StatusNotFound {
+		t.Errorf("restoring again: got %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// GetRoomTypes godoc
+// @Summary Get all room types
+// @Description Get the room type catalog with base prices in minor units of each type's currency
+// @Tags Room
+// @Produce  json
+// @Success 200 {array} models.RoomType
+// @Failure 500 {string} string "Internal server error"
+// @Router /room-types [get]
+func (h *Handler) GetRoomTypes(w http.ResponseWriter, r *http.Request) {
+	roomTypes, err := h.store.RoomTypes().FindAll()
+	if err != nil {
+		http.Error(w, "Failed to fetch room types.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(roomTypes)
+}
+
+// GetRoomType godoc
+// @Summary Get a room type
+// @Description Get a room type of the catalog by ID
+// @Tags Room
+// @Produce  json
+// @Param   room_type_id  path int  true  "Room type ID"
+// @Success 200 {object} models.RoomType
+// @Failure 400 {string} string "Invalid room type ID"
+// @Failure 404 {string} string "Room type not found"
+// @Router /room-types/{room_type_id} [get]
+func (h *Handler) GetRoomType(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	roomTypeID, err := strconv.Atoi(params["room_type_id"])
+	if err != nil {
+		http.Error(w, "Invalid room type id.", http.StatusBadRequest)
+		return
+	}
+
+	roomType, err := h.store.RoomTypes().FindByID(uint(roomTypeID))
+	if err != nil {
+		http.Error(w, "Room type not found.", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(roomType)
+}
+
+// CreateRoomType godoc
+// @Summary Create a room type
+// @Description Create a room type with a unique name, a description, a nightly base price (minor units of its currency, which defaults to the base currency), a maximum occupancy, the guests included in the price (default all of them), per-night surcharges for extra adults and children, beds, amenities and photo URLs
+// @Tags Room
+// @Accept  json
+// @Produce  json
+// @Param   room_type  body models.RoomType  true  "Room type"
+// @Success 201 {object} models.RoomType
+// @Failure 400 {string} string "Invalid input"
+// @Failure 409 {string} string "Room type name already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /room-types [post]
+func (h *Handler) CreateRoomType(w http.ResponseWriter, r *http.Request) {
+	var roomType models.RoomType
+	if err := json.NewDecoder(r.Body).Decode(&roomType); err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	if err := service.NormalizeRoomType(&roomType); err != nil {
+		writeRoomTypeError(w, err)
+		return
+	}
+
+	roomType.ID = 0
+	roomType.CreatedAt = time.Now()
+	roomType.UpdatedAt = time.Now()
+
+	if err := h.store.RoomTypes().Create(&roomType); err != nil {
+		writeRoomTypeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(roomType)
+}
+
+// UpdateRoomType godoc
+// @Summary Update a room type
+// @Description Update a room type. Every room of the type is sold at the new terms; reservations keep the prices they were booked at.
+// @Tags Room
+// @Accept  json
+// @Produce  json
+// @Param   room_type_id  path int  true  "Room type ID"
+// @Param   room_type  body models.RoomType  true  "Room type"
+// @Success 200 {object} models.RoomType
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Room type not found"
+// @Failure 409 {string} string "Room type name already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /room-types/{room_type_id} [put]
+func (h *Handler) UpdateRoomType(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	roomTypeID, err := strconv.Atoi(params["room_type_id"])
+	if err != nil {
+		http.Error(w, "Invalid room type id.", http.StatusBadRequest)
+		return
+	}
+
+	roomType, err := h.store.RoomTypes().FindByID(uint(roomTypeID))
+	if err != nil {
+		http.Error(w, "Room type not found.", http.StatusNotFound)
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(roomType); err != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+	if err := service.NormalizeRoomType(roomType); err != nil {
+		writeRoomTypeError(w, err)
+		return
+	}
+
+	roomType.ID = uint(roomTypeID)
+	roomType.UpdatedAt = time.Now()
+
+	if err := h.store.RoomTypes().Save(roomType); err != nil {
+		writeRoomTypeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(roomType)
+}
+
+// DeleteRoomType godoc
+// @Summary Delete a room type
+// @Description Delete a room type and its rate overrides. Types that rooms still belong to cannot be deleted.
+// @Tags Room
+// @Param   room_type_id  path int  true  "Room type ID"
+// @Success 204 {string} string "No Content"
+// @Failure 400 {string} string "Invalid room type ID"
+// @Failure 404 {string} string "Room type not found"
+// @Failure 409 {string} string "Room type still has rooms"
+// @Failure 500 {string} string "Internal server error"
+// @Router /room-types/{room_type_id} [delete]
+func (h *Handler) DeleteRoomType(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	roomTypeID, err := strconv.Atoi(params["room_type_id"])
+	if err != nil {
+		http.Error(w, "Invalid room type id.", http.StatusBadRequest)
+		return
+	}
+
+	err = service.DeleteRoomType(h.store, uint(roomTypeID))
+	if errors.Is(err, service.ErrUnknownRoomType) {
+		http.Error(w, "Room type not found.", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeRoomTypeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeRoomTypeError maps a room type lookup or write failure onto an HTTP
+// response.
+func writeRoomTypeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownRoomType):
+		http.Error(w, "Unknown room type.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidRoomType):
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidCurrency):
+		http.Error(w, "Invalid currency.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidOccupancy):
+		http.Error(w, "Invalid occupancy.", http.StatusBadRequest)
+	case errors.Is(err, service.ErrRoomTypeInUse):
+		http.Error(w, "Room type still has rooms.", http.StatusConflict)
+	case errors.Is(err, repository.ErrDuplicate):
+		http.Error(w, "Room type name already exists.", http.StatusConflict)
+	default:
+		http.Error(w, "Failed to save room type: "+err.Error(), http.StatusInternalServerError)
+	}
+}
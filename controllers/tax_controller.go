@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// GetTaxes godoc
+// @Summary Get all taxes
+// @Description Get every tax and fee, active or not
+// @Tags Taxes
+// @Produce  json
+// @Success 200 {array} models.Tax
+// @Failure 500 {string} string "Internal server error"
+// @Router /taxes [get]
+func (h *Handler) GetTaxes(w http.ResponseWriter, r *http.Request) {
+	taxes, err := h.store.Taxes().FindAll()
+	if err != nil {
+		http.Error(w, "Failed to fetch taxes.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(taxes)
+}
+
+// CreateTax godoc
+// @Summary Create a tax
+// @Description Create a tax charged on every night of new bookings: a percentage of the night (rate), a fixed amount per night or per guest per night (amount, minor units of the base currency), inclusive in or exclusive of the room price. Taxes are active unless created with active set to false.
+// @Tags Taxes
+// @Accept  json
+// @Produce  json
+// @Param   tax  body models.Tax  true  "Tax"
+// @Success 201 {object} models.Tax
+// @Failure 400 {string} string "Invalid input"
+// @Failure 409 {string} string "Tax code already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /taxes [post]
+func (h *Handler) CreateTax(w http.ResponseWriter, r *http.Request) {
+	tax := models.Tax{Active: true}
+	err := json.NewDecoder(r.Body).Decode(&tax)
+	if err != nil || service.ValidateTax(&tax) != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+
+	tax.ID = 0
+	tax.CreatedAt = time.Now()
+	tax.UpdatedAt = time.Now()
+
+	if err := h.store.Taxes().Create(&tax); err != nil {
+		writeTaxError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tax)
+}
+
+// UpdateTax godoc
+// @Summary Update a tax
+// @Description Update a tax. Reservations keep the taxes they were priced with.
+// @Tags Taxes
+// @Accept  json
+// @Produce  json
+// @Param   tax_id  path int  true  "Tax ID"
+// @Param   tax  body models.Tax  true  "Tax"
+// @Success 200 {object} models.Tax
+// @Failure 400 {string} string "Invalid input"
+// @Failure 404 {string} string "Tax not found"
+// @Failure 409 {string} string "Tax code already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /taxes/{tax_id} [put]
+func (h *Handler) UpdateTax(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	taxID, err := strconv.Atoi(params["tax_id"])
+	if err != nil {
+		http.Error(w, "Invalid tax id.", http.StatusBadRequest)
+		return
+	}
+
+	tax, err := h.store.Taxes().FindByID(uint(taxID))
+	if err != nil {
+		http.Error(w, "Tax not found.", http.StatusNotFound)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(tax)
+	if err != nil || service.ValidateTax(tax) != nil {
+		http.Error(w, "Invalid input.", http.StatusBadRequest)
+		return
+	}
+
+	tax.ID = uint(taxID)
+	tax.UpdatedAt = time.Now()
+
+	if err := h.store.Taxes().Save(tax); err != nil {
+		writeTaxError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tax)
+}
+
+// DeleteTax godoc
+// @Summary Delete a tax
+// @Description Delete a tax by ID. Reservations keep the taxes they were priced with.
+// @Tags Taxes
+// @Param   tax_id  path int  true  "Tax ID"
+// @Success 204 {string} string "No Content"
+// @Failure 400 {string} string "Invalid tax ID"
+// @Failure 500 {string} string "Internal server error"
+// @Router /taxes/{tax_id} [delete]
+func (h *Handler) DeleteTax(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	taxID, err := strconv.Atoi(params["tax_id"])
+	if err != nil {
+		http.Error(w, "Invalid tax id.", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store.Taxes().Delete(uint(taxID)); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		http.Error(w, "Failed to delete tax.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTaxError maps a tax write failure onto an HTTP response.
+func writeTaxError(w http.ResponseWriter, err error) {
+	if errors.Is(err, repository.ErrDuplicate) {
+		http.Error(w, "Tax code already exists.", http.StatusConflict)
+		return
+	}
+	http.Error(w, "Failed to save tax: "+err.Error(), http.StatusInternalServerError)
+}
@@ -2,8 +2,11 @@ package controllers
 
 import (
 	"encoding/json"
-	"hotel_management_system/database"
+	"errors"
+	"hotel_management_system/middleware"
 	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	service "hotel_management_system/services"
 	"net/http"
 	"strconv"
 	"time"
@@ -21,9 +24,9 @@ import (
 // @Failure 404 {string} string "Customers not found"
 // @Failure 500 {string} string "Internal server error"
 // @Router /customers [get]
-func GetCustomers(w http.ResponseWriter, r *http.Request) {
-	var customers []models.User
-	if result := database.DB.Where("role = ?", "customer").Find(&customers); result.Error != nil {
+func (h *Handler) GetCustomers(w http.ResponseWriter, r *http.Request) {
+	customers, err := h.store.Users().FindByRole("customer")
+	if err != nil {
 		http.Error(w, "Customers not found.", http.StatusNotFound)
 		return
 	}
@@ -34,7 +37,7 @@ func GetCustomers(w http.ResponseWriter, r *http.Request) {
 
 // GetUser godoc
 // @Summary Get user details
-// @Description Get details of a specific user by ID
+// @Description Get details of a specific user by ID. The ETag header carries its version for conditional updates.
 // @Tags User
 // @Produce  json
 // @Param   user_id  path int  true  "User ID"
@@ -43,7 +46,7 @@ func GetCustomers(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {string} string "User not found"
 // @Failure 500 {string} string "Internal server error"
 // @Router /users/{user_id} [get]
-func GetUser(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userID, err := strconv.Atoi(params["user_id"])
 	if err != nil {
@@ -51,8 +54,8 @@ func GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var user models.User
-	if result := database.DB.First(&user, userID); result.Error != nil {
+	user, err := h.store.Users().FindByID(uint(userID))
+	if err != nil {
 		http.Error(w, "User not found.", http.StatusNotFound)
 		return
 	}
@@ -68,13 +71,17 @@ func GetUser(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param   user_id  path int  true  "User ID"
+// @Param   If-Match  header string  true  "ETag of the user"
 // @Param   user  body models.User  true  "Updated user data"
 // @Success 200 {object} models.User
 // @Failure 400 {string} string "Invalid user id"
 // @Failure 404 {string} string "User not found"
+// @Failure 409 {string} string "Username or email taken by another or a deleted user"
+// @Failure 412 {string} string "User changed since it was read"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /users/{user_id} [put]
-func UpdateUser(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userID, err := strconv.Atoi(params["user_id"])
 	if err != nil {
@@ -82,8 +89,8 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var user models.User
-	if result := database.DB.First(&user, userID); result.Error != nil {
+	user, err := h.store.Users().FindByID(uint(userID))
+	if err != nil {
 		http.Error(w, "User not found.", http.StatusNotFound)
 		return
 	}
@@ -113,27 +120,33 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user.UpdatedAt = time.Now()
+	user.Version = middleware.ExpectedVersion(r)
 
-	if result := database.DB.Save(&user); result.Error != nil {
-		http.Error(w, "Failed to update user.", http.StatusInternalServerError)
+	if err := h.store.Users().Save(user); err != nil {
+		writeUserError(w, err, "Failed to update user.")
 		return
 	}
 
+	setETag(w, user.Version)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "User updated successfully."})
 }
 
 // DeleteUser godoc
 // @Summary Delete a user
-// @Description Delete a user by ID
+// @Description Delete a user by ID. A deleted user can be restored until it is purged.
 // @Tags User
 // @Param   user_id  path int  true  "User ID"
+// @Param   If-Match  header string  true  "ETag of the user"
 // @Success 204 {string} string "No Content"
 // @Failure 400 {string} string "Invalid user id"
 // @Failure 404 {string} string "User not found"
+// @Failure 409 {string} string "User has current or upcoming reservations"
+// @Failure 412 {string} string "User changed since it was read"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /users/{user_id} [delete]
-func DeleteUser(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userID, err := strconv.Atoi(params["user_id"])
 	if err != nil {
@@ -141,14 +154,15 @@ func DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var user models.User
-	if result := database.DB.First(&user, userID); result.Error != nil {
-		http.Error(w, "User not found.", http.StatusNotFound)
-		return
-	}
-
-	if result := database.DB.Delete(&user); result.Error != nil {
-		http.Error(w, "Failed to delete user.", http.StatusInternalServerError)
+	if err := service.DeleteUser(h.store, uint(userID), middleware.ExpectedVersion(r)); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			http.Error(w, "User not found.", http.StatusNotFound)
+		case errors.Is(err, service.ErrUserHasBookings):
+			http.Error(w, "User has pending, confirmed or checked-in reservations.", http.StatusConflict)
+		default:
+			writeSaveError(w, err, "Failed to delete user.")
+		}
 		return
 	}
 
@@ -165,9 +179,9 @@ func DeleteUser(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {string} string "Users not found"
 // @Failure 500 {string} string "Internal server error"
 // @Router /users [get]
-func GetAllUsers(w http.ResponseWriter, r *http.Request) {
-	var users []models.User
-	if result := database.DB.Find(&users); result.Error != nil {
+func (h *Handler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.store.Users().FindAll()
+	if err != nil {
 		http.Error(w, "Users not found.", http.StatusNotFound)
 		return
 	}
@@ -178,17 +192,17 @@ func GetAllUsers(w http.ResponseWriter, r *http.Request) {
 
 // GetProfile godoc
 // @Summary Get user profile
-// @Description Get the profile information of the currently logged-in user
+// @Description Get the profile information of the currently logged-in user. The ETag header carries its version for conditional updates.
 // @Tags Profile
 // @Produce  json
 // @Success 200 {object} models.User
 // @Failure 401 {string} string "Unauthorized"
 // @Router /profile [get]
-func GetProfile(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	claims := r.Context().Value("user").(*models.Claims)
 
-	var user models.User
-	if result := database.DB.First(&user, claims.UserID); result.Error != nil {
+	user, err := h.store.Users().FindByID(claims.UserID)
+	if err != nil {
 		http.Error(w, "User not found", http.StatusNotFound)
 		return
 	}
@@ -202,22 +216,25 @@ func GetProfile(w http.ResponseWriter, r *http.Request) {
 // @Tags Profile
 // @Accept  json
 // @Produce  json
+// @Param   If-Match  header string  true  "ETag of the profile"
 // @Param   user  body models.User  true  "Updated user data"
 // @Success 200 {object} models.User
 // @Failure 400 {string} string "Invalid input"
 // @Failure 401 {string} string "Unauthorized"
+// @Failure 412 {string} string "Profile changed since it was read"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /profile [put]
-func UpdateProfile(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	claims := r.Context().Value("user").(*models.Claims)
-	var user models.User
-	if result := database.DB.First(&user, claims.UserID); result.Error != nil {
+	user, err := h.store.Users().FindByID(claims.UserID)
+	if err != nil {
 		http.Error(w, "User not found.", http.StatusNotFound)
 		return
 	}
 
 	var input models.User
-	err := json.NewDecoder(r.Body).Decode(&input)
+	err = json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
 		http.Error(w, "Invalid input.", http.StatusBadRequest)
 		return
@@ -226,12 +243,14 @@ func UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	user.Email = input.Email
 	user.Username = input.Username
 	user.UpdatedAt = time.Now()
+	user.Version = middleware.ExpectedVersion(r)
 
-	if result := database.DB.Save(&user); result != nil {
-		http.Error(w, "Failed to update profile.", http.StatusInternalServerError)
+	if err := h.store.Users().Save(user); err != nil {
+		writeSaveError(w, err, "Failed to update profile.")
 		return
 	}
 
+	setETag(w, user.Version)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(user)
 }
@@ -242,22 +261,25 @@ func UpdateProfile(w http.ResponseWriter, r *http.Request) {
 // @Tags Profile
 // @Accept  json
 // @Produce  json
+// @Param   If-Match  header string  true  "ETag of the profile"
 // @Param   password_data  body map[string]string  true  "Old and new passwords"
 // @Success 200 {string} string "Password updated successfully"
 // @Failure 400 {string} string "Invalid input"
 // @Failure 401 {string} string "Unauthorized"
+// @Failure 412 {string} string "Profile changed since it was read"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /profile/password [put]
-func UpdatePassword(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdatePassword(w http.ResponseWriter, r *http.Request) {
 	claims := r.Context().Value("user").(*models.Claims)
-	var user models.User
-	if result := database.DB.First(&user, claims.UserID); result.Error != nil {
+	user, err := h.store.Users().FindByID(claims.UserID)
+	if err != nil {
 		http.Error(w, "User not found.", http.StatusNotFound)
 		return
 	}
 
 	var passwordData map[string]string
-	err := json.NewDecoder(r.Body).Decode(&passwordData)
+	err = json.NewDecoder(r.Body).Decode(&passwordData)
 	if err != nil {
 		http.Error(w, "Invalid input.", http.StatusBadRequest)
 		return
@@ -279,12 +301,64 @@ func UpdatePassword(w http.ResponseWriter, r *http.Request) {
 
 	user.Password = string(hashedPassword)
 	user.UpdatedAt = time.Now()
+	user.Version = middleware.ExpectedVersion(r)
 
-	if result := database.DB.Save(&user); result.Error != nil {
-		http.Error(w, "Failed to update password.", http.StatusInternalServerError)
+	if err := h.store.Users().Save(user); err != nil {
+		writeSaveError(w, err, "Failed to update password.")
 		return
 	}
 
+	setETag(w, user.Version)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Password updated successfully."})
 }
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Bring back a deleted user that has not been purged yet
+// @Tags User
+// @Produce  json
+// @Param   user_id  path int  true  "User ID"
+// @Success 200 {object} models.User
+// @Failure 400 {string} string "Invalid user id"
+// @Failure 404 {string} string "Deleted user not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /users/{user_id}/restore [post]
+func (h *Handler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userID, err := strconv.Atoi(params["user_id"])
+	if err != nil {
+		http.Error(w, "Invalid user id.", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store.Users().Restore(uint(userID)); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, "Deleted user not found.", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to restore user.", http.StatusInternalServerError)
+		return
+	}
+	user, err := h.store.Users().FindByID(uint(userID))
+	if err != nil {
+		http.Error(w, "Failed to restore user.", http.StatusInternalServerError)
+		return
+	}
+
+	setETag(w, user.Version)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+// writeUserError maps a user write failure onto an HTTP response.
+func writeUserError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrDeletedDuplicate):
+		http.Error(w, "Username or email belongs to a deleted user; restore it instead.", http.StatusConflict)
+	case errors.Is(err, repository.ErrDuplicate):
+		http.Error(w, "Username or email already exists.", http.StatusConflict)
+	default:
+		writeSaveError(w, err, message)
+	}
+}
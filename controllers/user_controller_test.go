@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"hotel_management_system/models"
+	"net/http"
+	"testing"
+)
+
+func TestDeleteUser(t *testing.T) {
+	h, store := newTestHandler(t)
+	booking := map[string]interface{}{"room_number": "101", "start_date": "2026-01-01T14:00:00Z", "end_date": "2026-01-03T11:00:00Z", "user_id": 1}
+	if w := serve(h.CreateReservation, http.MethodPost, booking, nil); w.Code != http.StatusCreated {
+		t.Fatalf("booking: got %d: %s", w.Code, w.Body)
+	}
+	if err := store.Users().Create(&models.User{Username: "other", Email: "other@example.com", Role: "customer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		version uint
+		want    int
+	}{
+		{name: "unknown user", userID: "9", version: 1, want: http.StatusNotFound},
+		{name: "user with a pending reservation", userID: "1", version: 1, want: http.StatusConflict},
+		{name: "stale version", userID: "2", version: 2, want: http.StatusPreconditionFailed},
+		{name: "user without reservations", userID: "2", version: 1, want: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(atVersion(tt.version, h.DeleteUser), http.MethodDelete, nil, map[string]string{"user_id": tt.userID})
+			if w.Code != tt.want {
+				t.Errorf("got %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
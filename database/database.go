@@ -1,27 +1,65 @@
 package database
 
 import (
-	"hotel_management_system/models"
+	"fmt"
+	"hotel_management_system/database/migrations"
 	"log"
 	"os"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
+// Connect opens the database selected by DB_DRIVER (mysql, postgres or
+// sqlite, defaulting to mysql) using the DSN in DSL.
 func Connect() {
+	driver := os.Getenv("DB_DRIVER")
 	dsn := os.Getenv("DSL")
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+
+	dialector, err := Dialector(driver, dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
 		log.Fatal("Failed to connect to database: ", err)
 	}
+
+	// SQLite allows a single writer at a time. Funnelling everything through
+	// one connection makes concurrent transactions queue instead of failing
+	// with SQLITE_BUSY.
+	if driver == "sqlite" {
+		sqlDB, err := db.DB()
+		if err != nil {
+			log.Fatal("Failed to configure database: ", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
 	DB = db
 }
 
+// Dialector returns the GORM dialector for the named driver.
+func Dialector(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case "", "mysql":
+		return mysql.Open(dsn), nil
+	case "postgres":
+		return postgres.Open(dsn), nil
+	case "sqlite":
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q", driver)
+	}
+}
+
+// Migrate applies every pending schema migration.
 func Migrate() {
-	DB.AutoMigrate(&models.User{})
-	DB.AutoMigrate(&models.Room{})
-	DB.AutoMigrate(&models.Reservation{})
+	if err := migrations.Up(DB); err != nil {
+		log.Fatal("Failed to migrate database: ", err)
+	}
 }
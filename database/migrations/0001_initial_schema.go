@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The initial schema matches what AutoMigrate used to create, so applying it
+// to an existing database is a no-op.
+
+type user0001 struct {
+	ID        uint   `gorm:"primaryKey"`
+	Username  string `gorm:"unique;not null"`
+	Password  string `gorm:"not null"`
+	Email     string `gorm:"unique; not null"`
+	Role      string `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (user0001) TableName() string { return "users" }
+
+type room0001 struct {
+	ID        uint    `gorm:"primaryKey"`
+	Number    string  `gorm:"unique;not null"`
+	Type      string  `gorm:"not null"`
+	Status    string  `gorm:"not null"`
+	Price     float64 `gorm:"not null"`
+	CreatedAt time.Time
+	UpdateAt  time.Time
+}
+
+func (room0001) TableName() string { return "rooms" }
+
+type reservation0001 struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"int"`
+	RoomID    uint `gorm:"int"`
+	StartDate time.Time
+	EndDate   time.Time
+	Status    string `gorm:"string"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (reservation0001) TableName() string { return "reservations" }
+
+func init() {
+	register(Migration{
+		Version: 1,
+		Name:    "initial_schema",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&user0001{}, &room0001{}, &reservation0001{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&reservation0001{}, &room0001{}, &user0001{})
+		},
+	})
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type reservation0002 struct {
+	StatusUpdatedBy uint
+	StatusUpdatedAt *time.Time
+}
+
+func (reservation0002) TableName() string { return "reservations" }
+
+func init() {
+	register(Migration{
+		Version: 2,
+		Name:    "reservation_status_audit",
+		Up: func(tx *gorm.DB) error {
+			for _, column := range []string{"StatusUpdatedBy", "StatusUpdatedAt"} {
+				if err := tx.Migrator().AddColumn(&reservation0002{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, column := range []string{"StatusUpdatedBy", "StatusUpdatedAt"} {
+				if err := tx.Migrator().DropColumn(&reservation0002{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
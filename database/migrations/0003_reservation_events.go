@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type reservationEvent0003 struct {
+	ID            uint `gorm:"primaryKey"`
+	ReservationID uint `gorm:"not null;index"`
+	UserID        uint
+	Field         string `gorm:"not null"`
+	OldValue      string
+	NewValue      string
+	CreatedAt     time.Time
+}
+
+func (reservationEvent0003) TableName() string { return "reservation_events" }
+
+func init() {
+	register(Migration{
+		Version: 3,
+		Name:    "reservation_events",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&reservationEvent0003{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&reservationEvent0003{})
+		},
+	})
+}
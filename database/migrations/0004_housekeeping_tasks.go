@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type housekeepingTask0004 struct {
+	ID            uint `gorm:"primaryKey"`
+	RoomID        uint `gorm:"not null;index"`
+	ReservationID uint
+	Status        string `gorm:"not null"`
+	AssignedTo    uint
+	ClaimedAt     *time.Time
+	CompletedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (housekeepingTask0004) TableName() string { return "housekeeping_tasks" }
+
+func init() {
+	register(Migration{
+		Version: 4,
+		Name:    "housekeeping_tasks",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&housekeepingTask0004{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&housekeepingTask0004{})
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ratePlan0005 struct {
+	ID                uint   `gorm:"primaryKey"`
+	Code              string `gorm:"unique;not null"`
+	Name              string `gorm:"not null"`
+	PercentAdjustment float64
+	NightlySupplement int64
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (ratePlan0005) TableName() string { return "rate_plans" }
+
+type rateOverride0005 struct {
+	ID        uint   `gorm:"primaryKey"`
+	RoomType  string `gorm:"not null;index"`
+	StartDate *time.Time
+	EndDate   *time.Time
+	Weekdays  string
+	Amount    int64 `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (rateOverride0005) TableName() string { return "rate_overrides" }
+
+type reservationNight0005 struct {
+	ID            uint      `gorm:"primaryKey"`
+	ReservationID uint      `gorm:"not null;index"`
+	Date          time.Time `gorm:"not null"`
+	BaseAmount    int64
+	Amount        int64 `gorm:"not null"`
+}
+
+func (reservationNight0005) TableName() string { return "reservation_nights" }
+
+type reservation0005 struct {
+	RatePlanID uint
+}
+
+func (reservation0005) TableName() string { return "reservations" }
+
+func init() {
+	register(Migration{
+		Version: 5,
+		Name:    "rate_engine",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&ratePlan0005{}, &rateOverride0005{}, &reservationNight0005{}); err != nil {
+				return err
+			}
+			if err := tx.Migrator().AddColumn(&reservation0005{}, "RatePlanID"); err != nil {
+				return err
+			}
+
+			now := time.Now()
+			plans := []ratePlan0005{
+				{Code: "standard", Name: "Standard rate", CreatedAt: now, UpdatedAt: now},
+				{Code: "non-refundable", Name: "Non-refundable", PercentAdjustment: -10, CreatedAt: now, UpdatedAt: now},
+				{Code: "breakfast-included", Name: "Breakfast included", NightlySupplement: 1500, CreatedAt: now, UpdatedAt: now},
+			}
+			if err := tx.Create(&plans).Error; err != nil {
+				return err
+			}
+
+			// Existing bookings were all made at the standard rate.
+			return tx.Model(&reservation0005{}).Where("1 = 1").Update("rate_plan_id", plans[0].ID).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&reservation0005{}, "RatePlanID"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&reservationNight0005{}, &rateOverride0005{}, &ratePlan0005{})
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package migrations
+
+import (
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type reservation0006 struct {
+	ID          uint `gorm:"primaryKey"`
+	RoomID      uint
+	StartDate   time.Time
+	EndDate     time.Time
+	Currency    string `gorm:"size:3"`
+	TotalAmount int64
+}
+
+func (reservation0006) TableName() string { return "reservations" }
+
+type reservationNight0006 struct {
+	ID            uint `gorm:"primaryKey"`
+	ReservationID uint
+	Date          time.Time
+	BaseAmount    int64
+	Amount        int64
+}
+
+func (reservationNight0006) TableName() string { return "reservation_nights" }
+
+type room0006 struct {
+	ID    uint `gorm:"primaryKey"`
+	Price float64
+}
+
+func (room0006) TableName() string { return "rooms" }
+
+// stayDates0006 returns the UTC day of every night between start and end,
+// counting a same-day stay as one night.
+func stayDates0006(start, end time.Time) []time.Time {
+	sy, sm, sd := start.UTC().Date()
+	ey, em, ed := end.UTC().Date()
+	first := time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC)
+	last := time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC)
+
+	dates := []time.Time{first}
+	for day := first.AddDate(0, 0, 1); day.Before(last); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day)
+	}
+	return dates
+}
+
+// backfillTotals0006 stores a total for every reservation. Reservations
+// priced since migration 0005 already have nights; older ones never stored a
+// price, so their nights are rebuilt from the current room price, which is
+// the best record left of what they cost.
+func backfillTotals0006(tx *gorm.DB) error {
+	var reservations []reservation0006
+	if err := tx.Find(&reservations).Error; err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		var nights []reservationNight0006
+		if err := tx.Where("reservation_id = ?", reservation.ID).Find(&nights).Error; err != nil {
+			return err
+		}
+
+		if len(nights) == 0 {
+			var room room0006
+			if err := tx.Limit(1).Find(&room, reservation.RoomID).Error; err != nil {
+				return err
+			}
+			price := int64(math.Round(room.Price * 100))
+			for _, date := range stayDates0006(reservation.StartDate, reservation.EndDate) {
+				nights = append(nights, reservationNight0006{ReservationID: reservation.ID, Date: date, BaseAmount: price, Amount: price})
+			}
+			if err := tx.Create(&nights).Error; err != nil {
+				return err
+			}
+		}
+
+		var total int64
+		for _, night := range nights {
+			total += night.Amount
+		}
+		if err := tx.Model(&reservation0006{}).Where("id = ?", reservation.ID).
+			Updates(map[string]interface{}{"currency": "USD", "total_amount": total}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	register(Migration{
+		Version: 6,
+		Name:    "reservation_totals",
+		Up: func(tx *gorm.DB) error {
+			for _, column := range []string{"Currency", "TotalAmount"} {
+				if err := tx.Migrator().AddColumn(&reservation0006{}, column); err != nil {
+					return err
+				}
+			}
+			return backfillTotals0006(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, column := range []string{"Currency", "TotalAmount"} {
+				if err := tx.Migrator().DropColumn(&reservation0006{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type folio0007 struct {
+	ID            uint   `gorm:"primaryKey"`
+	ReservationID uint   `gorm:"not null;uniqueIndex"`
+	Currency      string `gorm:"size:3"`
+	Balance       int64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (folio0007) TableName() string { return "folios" }
+
+type folioLine0007 struct {
+	ID          uint   `gorm:"primaryKey"`
+	FolioID     uint   `gorm:"not null;index"`
+	Type        string `gorm:"not null"`
+	Category    string `gorm:"not null"`
+	Description string
+	Amount      int64 `gorm:"not null"`
+	Balance     int64
+	PostedBy    uint
+	CreatedAt   time.Time
+}
+
+func (folioLine0007) TableName() string { return "folio_lines" }
+
+func init() {
+	register(Migration{
+		Version: 7,
+		Name:    "folios",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&folio0007{}, &folioLine0007{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&folioLine0007{}, &folio0007{})
+		},
+	})
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type payment0008 struct {
+	ID            uint   `gorm:"primaryKey"`
+	ReservationID uint   `gorm:"not null;index"`
+	Kind          string `gorm:"not null"`
+	Status        string `gorm:"not null"`
+	Amount        int64  `gorm:"not null"`
+	Currency      string `gorm:"size:3"`
+	Reference     string
+	RefundOf      uint
+	CreatedBy     uint
+	CreatedAt     time.Time
+}
+
+func (payment0008) TableName() string { return "payments" }
+
+type ratePlan0008 struct {
+	DepositPercent     float64 `gorm:"not null;default:0"`
+	CancellationPolicy string  `gorm:"not null;default:flexible"`
+}
+
+func (ratePlan0008) TableName() string { return "rate_plans" }
+
+func init() {
+	register(Migration{
+		Version: 8,
+		Name:    "payments",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&payment0008{}); err != nil {
+				return err
+			}
+			for _, column := range []string{"DepositPercent", "CancellationPolicy"} {
+				if err := tx.Migrator().AddColumn(&ratePlan0008{}, column); err != nil {
+					return err
+				}
+			}
+
+			// The seeded non-refundable plan is paid in full when booking.
+			return tx.Model(&ratePlan0008{}).Where("code = ?", "non-refundable").
+				Updates(map[string]interface{}{"deposit_percent": 100, "cancellation_policy": "non-refundable"}).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, column := range []string{"DepositPercent", "CancellationPolicy"} {
+				if err := tx.Migrator().DropColumn(&ratePlan0008{}, column); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&payment0008{})
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+type ratePlan0009 struct {
+	FreeCancellationDays int `gorm:"not null;default:0"`
+}
+
+func (ratePlan0009) TableName() string { return "rate_plans" }
+
+func init() {
+	register(Migration{
+		Version: 9,
+		Name:    "cancellation_policies",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AddColumn(&ratePlan0009{}, "FreeCancellationDays")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&ratePlan0009{}, "FreeCancellationDays")
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type invoice0010 struct {
+	ID            uint   `gorm:"primaryKey"`
+	Number        string `gorm:"not null;uniqueIndex"`
+	Year          int    `gorm:"not null"`
+	Sequence      int    `gorm:"not null"`
+	ReservationID uint   `gorm:"not null;uniqueIndex"`
+	UserID        uint
+	Currency      string `gorm:"size:3"`
+	Subtotal      int64
+	TaxTotal      int64
+	Total         int64
+	Paid          int64
+	BalanceDue    int64
+	IssuedBy      uint
+	IssuedAt      time.Time
+}
+
+func (invoice0010) TableName() string { return "invoices" }
+
+type invoiceLine0010 struct {
+	ID          uint   `gorm:"primaryKey"`
+	InvoiceID   uint   `gorm:"not null;index"`
+	Kind        string `gorm:"not null"`
+	Description string
+	Date        *time.Time
+	Amount      int64
+}
+
+func (invoiceLine0010) TableName() string { return "invoice_lines" }
+
+type invoiceSequence0010 struct {
+	Year       int `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int `gorm:"not null"`
+}
+
+func (invoiceSequence0010) TableName() string { return "invoice_sequences" }
+
+func init() {
+	register(Migration{
+		Version: 10,
+		Name:    "invoices",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&invoice0010{}, &invoiceLine0010{}, &invoiceSequence0010{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&invoiceSequence0010{}, &invoiceLine0010{}, &invoice0010{})
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type tax0011 struct {
+	ID        uint   `gorm:"primaryKey"`
+	Code      string `gorm:"unique;not null"`
+	Name      string `gorm:"not null"`
+	Kind      string `gorm:"not null"`
+	Rate      float64
+	Amount    int64
+	Inclusive bool
+	Active    bool `gorm:"not null;default:true"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (tax0011) TableName() string { return "taxes" }
+
+type reservationTax0011 struct {
+	ID            uint `gorm:"primaryKey"`
+	ReservationID uint `gorm:"not null;index"`
+	TaxID         uint
+	Name          string
+	Date          time.Time `gorm:"not null"`
+	Amount        int64     `gorm:"not null"`
+	Inclusive     bool
+}
+
+func (reservationTax0011) TableName() string { return "reservation_taxes" }
+
+type reservation0011 struct {
+	TaxAmount int64 `gorm:"not null;default:0"`
+}
+
+func (reservation0011) TableName() string { return "reservations" }
+
+type invoiceLine0011 struct {
+	Included bool `gorm:"not null;default:false"`
+}
+
+func (invoiceLine0011) TableName() string { return "invoice_lines" }
+
+func init() {
+	register(Migration{
+		Version: 11,
+		Name:    "taxes",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&tax0011{}, &reservationTax0011{}); err != nil {
+				return err
+			}
+			if err := tx.Migrator().AddColumn(&reservation0011{}, "TaxAmount"); err != nil {
+				return err
+			}
+			return tx.Migrator().AddColumn(&invoiceLine0011{}, "Included")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&invoiceLine0011{}, "Included"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&reservation0011{}, "TaxAmount"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&reservationTax0011{}, &tax0011{})
+		},
+	})
+}
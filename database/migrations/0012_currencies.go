@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type room0012 struct {
+	ID          uint `gorm:"primaryKey"`
+	Price       float64
+	PriceAmount int64  `gorm:"not null;default:0"`
+	Currency    string `gorm:"size:3;not null;default:USD"`
+}
+
+func (room0012) TableName() string { return "rooms" }
+
+type exchangeRate0012 struct {
+	ID        uint      `gorm:"primaryKey"`
+	Currency  string    `gorm:"size:3;not null;uniqueIndex:idx_exchange_rates_currency_date"`
+	Date      time.Time `gorm:"not null;uniqueIndex:idx_exchange_rates_currency_date"`
+	Rate      float64   `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (exchangeRate0012) TableName() string { return "exchange_rates" }
+
+func init() {
+	register(Migration{
+		Version: 12,
+		Name:    "currencies",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&exchangeRate0012{}); err != nil {
+				return err
+			}
+
+			// Room prices become minor units with a currency. They were
+			// implicitly in USD, the currency migration 0006 stamped on
+			// reservations.
+			for _, column := range []string{"PriceAmount", "Currency"} {
+				if err := tx.Migrator().AddColumn(&room0012{}, column); err != nil {
+					return err
+				}
+			}
+			var rooms []room0012
+			if err := tx.Find(&rooms).Error; err != nil {
+				return err
+			}
+			for _, room := range rooms {
+				if err := tx.Model(&room0012{}).Where("id = ?", room.ID).
+					Update("price_amount", int64(math.Round(room.Price*100))).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Migrator().DropColumn(&room0012{}, "Price"); err != nil {
+				return err
+			}
+			return tx.Migrator().RenameColumn(&room0012{}, "price_amount", "price")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().RenameColumn(&room0012{}, "price", "price_amount"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().AddColumn(&room0012{}, "Price"); err != nil {
+				return err
+			}
+			var rooms []room0012
+			if err := tx.Find(&rooms).Error; err != nil {
+				return err
+			}
+			for _, room := range rooms {
+				if err := tx.Model(&room0012{}).Where("id = ?", room.ID).
+					Update("price", float64(room.PriceAmount)/100).Error; err != nil {
+					return err
+				}
+			}
+			for _, column := range []string{"PriceAmount", "Currency"} {
+				if err := tx.Migrator().DropColumn(&room0012{}, column); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&exchangeRate0012{})
+		},
+	})
+}
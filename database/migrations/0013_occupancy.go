@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+type reservation0013 struct {
+	Adults   int `gorm:"not null;default:1"`
+	Children int `gorm:"not null;default:0"`
+}
+
+func (reservation0013) TableName() string { return "reservations" }
+
+type room0013 struct {
+	MaxOccupancy     int `gorm:"not null;default:1"`
+	BaseOccupancy    int `gorm:"not null;default:1"`
+	Beds             string
+	ExtraAdultAmount int64 `gorm:"not null;default:0"`
+	ExtraChildAmount int64 `gorm:"not null;default:0"`
+}
+
+func (room0013) TableName() string { return "rooms" }
+
+type reservationNight0013 struct {
+	ExtraPersonAmount int64 `gorm:"not null;default:0"`
+}
+
+func (reservationNight0013) TableName() string { return "reservation_nights" }
+
+var roomColumns0013 = []string{"MaxOccupancy", "BaseOccupancy", "Beds", "ExtraAdultAmount", "ExtraChildAmount"}
+
+func init() {
+	register(Migration{
+		Version: 13,
+		Name:    "occupancy",
+		Up: func(tx *gorm.DB) error {
+			for _, column := range []string{"Adults", "Children"} {
+				if err := tx.Migrator().AddColumn(&reservation0013{}, column); err != nil {
+					return err
+				}
+			}
+			for _, column := range roomColumns0013 {
+				if err := tx.Migrator().AddColumn(&room0013{}, column); err != nil {
+					return err
+				}
+			}
+			if err := tx.Migrator().AddColumn(&reservationNight0013{}, "ExtraPersonAmount"); err != nil {
+				return err
+			}
+
+			// Existing rooms sleep what their type used to allow in the
+			// availability search, all of it included in the price.
+			for roomType, capacity := range map[string]int{"double": 2, "suite": 4} {
+				if err := tx.Model(&room0013{}).Where("type = ?", roomType).
+					Updates(map[string]interface{}{"max_occupancy": capacity, "base_occupancy": capacity}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&reservationNight0013{}, "ExtraPersonAmount"); err != nil {
+				return err
+			}
+			for _, column := range roomColumns0013 {
+				if err := tx.Migrator().DropColumn(&room0013{}, column); err != nil {
+					return err
+				}
+			}
+			for _, column := range []string{"Adults", "Children"} {
+				if err := tx.Migrator().DropColumn(&reservation0013{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,229 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type roomType0014 struct {
+	ID               uint   `gorm:"primaryKey"`
+	Name             string `gorm:"unique;not null"`
+	Description      string
+	BasePrice        int64  `gorm:"not null"`
+	Currency         string `gorm:"size:3;not null"`
+	MaxOccupancy     int    `gorm:"not null;default:1"`
+	BaseOccupancy    int    `gorm:"not null;default:1"`
+	Beds             string
+	ExtraAdultAmount int64  `gorm:"not null;default:0"`
+	ExtraChildAmount int64  `gorm:"not null;default:0"`
+	Amenities        string `gorm:"type:text"`
+	Photos           string `gorm:"type:text"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (roomType0014) TableName() string { return "room_types" }
+
+type room0014 struct {
+	ID               uint   `gorm:"primaryKey"`
+	Type             string `gorm:"not null;default:''"`
+	Price            int64  `gorm:"not null;default:0"`
+	Currency         string `gorm:"size:3;not null;default:USD"`
+	MaxOccupancy     int    `gorm:"not null;default:1"`
+	BaseOccupancy    int    `gorm:"not null;default:1"`
+	Beds             string
+	ExtraAdultAmount int64 `gorm:"not null;default:0"`
+	ExtraChildAmount int64 `gorm:"not null;default:0"`
+	RoomTypeID       uint  `gorm:"not null;default:0;index"`
+}
+
+func (room0014) TableName() string { return "rooms" }
+
+type reservation0014 struct {
+	RoomTypeID uint `gorm:"not null;default:0;index"`
+}
+
+func (reservation0014) TableName() string { return "reservations" }
+
+type rateOverride0014 struct {
+	ID         uint   `gorm:"primaryKey"`
+	RoomType   string `gorm:"not null;default:'';index"`
+	RoomTypeID uint   `gorm:"not null;default:0;index"`
+	StartDate  *time.Time
+	EndDate    *time.Time
+	Weekdays   string
+	Amount     int64 `gorm:"not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (rateOverride0014) TableName() string { return "rate_overrides" }
+
+// roomColumns0014 are the room columns that move to the room type.
+var roomColumns0014 = []string{"Type", "Price", "Currency", "MaxOccupancy", "BaseOccupancy", "Beds", "ExtraAdultAmount", "ExtraChildAmount"}
+
+// roomTerms0014 is what rooms of one type have to share to become one room
+// type.
+type roomTerms0014 struct {
+	Type             string
+	Price            int64
+	Currency         string
+	MaxOccupancy     int
+	BaseOccupancy    int
+	Beds             string
+	ExtraAdultAmount int64
+	ExtraChildAmount int64
+}
+
+func init() {
+	register(Migration{
+		Version: 14,
+		Name:    "room_types",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&roomType0014{}); err != nil {
+				return err
+			}
+			for _, model := range []interface{}{&room0014{}, &reservation0014{}, &rateOverride0014{}} {
+				if err := tx.Migrator().AddColumn(model, "RoomTypeID"); err != nil {
+					return err
+				}
+			}
+
+			// Every distinct combination of type name and terms becomes a
+			// room type. Rooms that shared a name but were priced or sized
+			// differently become numbered variants ("double 2") so that no
+			// room changes price.
+			var rooms []room0014
+			if err := tx.Order("id").Find(&rooms).Error; err != nil {
+				return err
+			}
+			typeIDs := make(map[roomTerms0014]uint)
+			variants := make(map[string][]uint)
+			now := time.Now()
+			for _, room := range rooms {
+				terms := roomTerms0014{room.Type, room.Price, room.Currency, room.MaxOccupancy, room.BaseOccupancy, room.Beds, room.ExtraAdultAmount, room.ExtraChildAmount}
+				id, ok := typeIDs[terms]
+				if !ok {
+					name := room.Type
+					if n := len(variants[room.Type]); n > 0 {
+						name = fmt.Sprintf("%s %d", room.Type, n+1)
+					}
+					roomType := roomType0014{
+						Name: name, BasePrice: room.Price, Currency: room.Currency,
+						MaxOccupancy: room.MaxOccupancy, BaseOccupancy: room.BaseOccupancy, Beds: room.Beds,
+						ExtraAdultAmount: room.ExtraAdultAmount, ExtraChildAmount: room.ExtraChildAmount,
+						Amenities: "[]", Photos: "[]", CreatedAt: now, UpdatedAt: now,
+					}
+					if err := tx.Create(&roomType).Error; err != nil {
+						return err
+					}
+					id = roomType.ID
+					typeIDs[terms] = id
+					variants[room.Type] = append(variants[room.Type], id)
+				}
+				if err := tx.Model(&room0014{}).Where("id = ?", room.ID).UpdateColumn("room_type_id", id).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Exec("UPDATE reservations SET room_type_id = (SELECT rooms.room_type_id FROM rooms WHERE rooms.id = reservations.room_id)" +
+				" WHERE EXISTS (SELECT 1 FROM rooms WHERE rooms.id = reservations.room_id)").Error; err != nil {
+				return err
+			}
+
+			// Overrides apply to every variant of their type. Overrides for
+			// a type without rooms keep it alive as an empty room type.
+			var overrides []rateOverride0014
+			if err := tx.Order("id").Find(&overrides).Error; err != nil {
+				return err
+			}
+			for _, override := range overrides {
+				ids := variants[override.RoomType]
+				if len(ids) == 0 {
+					roomType := roomType0014{
+						Name: override.RoomType, Currency: "USD", Amenities: "[]", Photos: "[]", CreatedAt: now, UpdatedAt: now,
+					}
+					if err := tx.Create(&roomType).Error; err != nil {
+						return err
+					}
+					ids = []uint{roomType.ID}
+					variants[override.RoomType] = ids
+				}
+				if err := tx.Model(&rateOverride0014{}).Where("id = ?", override.ID).UpdateColumn("room_type_id", ids[0]).Error; err != nil {
+					return err
+				}
+				for _, id := range ids[1:] {
+					copied := override
+					copied.ID = 0
+					copied.RoomTypeID = id
+					if err := tx.Create(&copied).Error; err != nil {
+						return err
+					}
+				}
+			}
+
+			if err := tx.Migrator().DropIndex(&rateOverride0014{}, "RoomType"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&rateOverride0014{}, "RoomType"); err != nil {
+				return err
+			}
+			for _, column := range roomColumns0014 {
+				if err := tx.Migrator().DropColumn(&room0014{}, column); err != nil {
+					return err
+				}
+			}
+
+			// Indexes come last because SQLite rebuilds a table, and loses
+			// its indexes, to drop a column.
+			for _, model := range []interface{}{&room0014{}, &reservation0014{}, &rateOverride0014{}} {
+				if err := tx.Migrator().CreateIndex(model, "RoomTypeID"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, column := range roomColumns0014 {
+				if err := tx.Migrator().AddColumn(&room0014{}, column); err != nil {
+					return err
+				}
+			}
+			if err := tx.Migrator().AddColumn(&rateOverride0014{}, "RoomType"); err != nil {
+				return err
+			}
+
+			var roomTypes []roomType0014
+			if err := tx.Find(&roomTypes).Error; err != nil {
+				return err
+			}
+			for _, roomType := range roomTypes {
+				if err := tx.Model(&room0014{}).Where("room_type_id = ?", roomType.ID).Updates(map[string]interface{}{
+					"type": roomType.Name, "price": roomType.BasePrice, "currency": roomType.Currency,
+					"max_occupancy": roomType.MaxOccupancy, "base_occupancy": roomType.BaseOccupancy, "beds": roomType.Beds,
+					"extra_adult_amount": roomType.ExtraAdultAmount, "extra_child_amount": roomType.ExtraChildAmount,
+				}).Error; err != nil {
+					return err
+				}
+				if err := tx.Model(&rateOverride0014{}).Where("room_type_id = ?", roomType.ID).UpdateColumn("room_type", roomType.Name).Error; err != nil {
+					return err
+				}
+			}
+
+			for _, model := range []interface{}{&rateOverride0014{}, &reservation0014{}, &room0014{}} {
+				if err := tx.Migrator().DropIndex(model, "RoomTypeID"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(model, "RoomTypeID"); err != nil {
+					return err
+				}
+			}
+			if err := tx.Migrator().CreateIndex(&rateOverride0014{}, "RoomType"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&roomType0014{})
+		},
+	})
+}
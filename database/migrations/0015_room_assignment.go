@@ -0,0 +1,84 @@
+package migrations
+
+import (
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+type room0015 struct {
+	ID         uint `gorm:"primaryKey"`
+	Number     string
+	Floor      int  `gorm:"not null;default:0"`
+	Accessible bool `gorm:"not null;default:false"`
+	RoomTypeID uint `gorm:"index"`
+}
+
+func (room0015) TableName() string { return "rooms" }
+
+type reservation0015 struct {
+	AutoAssigned bool   `gorm:"not null;default:false"`
+	Preferences  string `gorm:"not null;default:''"`
+	RoomTypeID   uint   `gorm:"index"`
+}
+
+func (reservation0015) TableName() string { return "reservations" }
+
+func init() {
+	register(Migration{
+		Version: 15,
+		Name:    "room_assignment",
+		Up: func(tx *gorm.DB) error {
+			for _, column := range []string{"Floor", "Accessible"} {
+				if err := tx.Migrator().AddColumn(&room0015{}, column); err != nil {
+					return err
+				}
+			}
+			for _, column := range []string{"AutoAssigned", "Preferences"} {
+				if err := tx.Migrator().AddColumn(&reservation0015{}, column); err != nil {
+					return err
+				}
+			}
+
+			// Rooms numbered the usual way, 204 for the fourth room on the
+			// second floor, get their floor from the number. Other rooms
+			// stay on floor 0 until an admin sets it.
+			var rooms []room0015
+			if err := tx.Find(&rooms).Error; err != nil {
+				return err
+			}
+			for _, room := range rooms {
+				number, err := strconv.Atoi(room.Number)
+				if err != nil || number < 100 {
+					continue
+				}
+				if err := tx.Model(&room0015{}).Where("id = ?", room.ID).UpdateColumn("floor", number/100).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, column := range []string{"AutoAssigned", "Preferences"} {
+				if err := tx.Migrator().DropColumn(&reservation0015{}, column); err != nil {
+					return err
+				}
+			}
+			for _, column := range []string{"Floor", "Accessible"} {
+				if err := tx.Migrator().DropColumn(&room0015{}, column); err != nil {
+					return err
+				}
+			}
+			// SQLite rebuilds a table, and loses its indexes, to drop a
+			// column.
+			for _, model := range []interface{}{&room0015{}, &reservation0015{}} {
+				if !tx.Migrator().HasIndex(model, "RoomTypeID") {
+					if err := tx.Migrator().CreateIndex(model, "RoomTypeID"); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	})
+}
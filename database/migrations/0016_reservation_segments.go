@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type reservationSegment0016 struct {
+	ID            uint      `gorm:"primaryKey"`
+	ReservationID uint      `gorm:"not null;index"`
+	RoomID        uint      `gorm:"not null;index"`
+	StartDate     time.Time `gorm:"not null"`
+	EndDate       time.Time `gorm:"not null"`
+}
+
+func (reservationSegment0016) TableName() string { return "reservation_segments" }
+
+type reservationNight0016 struct {
+	ReservationID uint `gorm:"not null;index"`
+	RoomID        uint `gorm:"not null;default:0;index"`
+}
+
+func (reservationNight0016) TableName() string { return "reservation_nights" }
+
+func init() {
+	register(Migration{
+		Version: 16,
+		Name:    "reservation_segments",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&reservationSegment0016{}); err != nil {
+				return err
+			}
+			if err := tx.Migrator().AddColumn(&reservationNight0016{}, "RoomID"); err != nil {
+				return err
+			}
+			// No guest has moved yet, so every night was spent in the room
+			// of its reservation.
+			if err := tx.Exec("UPDATE reservation_nights SET room_id = (SELECT reservations.room_id FROM reservations WHERE reservations.id = reservation_nights.reservation_id)" +
+				" WHERE EXISTS (SELECT 1 FROM reservations WHERE reservations.id = reservation_nights.reservation_id)").Error; err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&reservationNight0016{}, "RoomID")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&reservationNight0016{}, "RoomID"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&reservationNight0016{}, "RoomID"); err != nil {
+				return err
+			}
+			// SQLite rebuilds a table, and loses its indexes, to drop a
+			// column.
+			if !tx.Migrator().HasIndex(&reservationNight0016{}, "ReservationID") {
+				if err := tx.Migrator().CreateIndex(&reservationNight0016{}, "ReservationID"); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&reservationSegment0016{})
+		},
+	})
+}
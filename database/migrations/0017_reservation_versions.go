@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+type reservation0017 struct {
+	Version    uint `gorm:"not null;default:1"`
+	RoomTypeID uint `gorm:"index"`
+}
+
+func (reservation0017) TableName() string { return "reservations" }
+
+func init() {
+	register(Migration{
+		Version: 17,
+		Name:    "reservation_versions",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AddColumn(&reservation0017{}, "Version")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&reservation0017{}, "Version"); err != nil {
+				return err
+			}
+			// SQLite rebuilds a table, and loses its indexes, to drop a
+			// column.
+			if !tx.Migrator().HasIndex(&reservation0017{}, "RoomTypeID") {
+				return tx.Migrator().CreateIndex(&reservation0017{}, "RoomTypeID")
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+type user0018 struct {
+	Version uint `gorm:"not null;default:1"`
+}
+
+func (user0018) TableName() string { return "users" }
+
+type room0018 struct {
+	Version    uint `gorm:"not null;default:1"`
+	RoomTypeID uint `gorm:"index"`
+}
+
+func (room0018) TableName() string { return "rooms" }
+
+func init() {
+	register(Migration{
+		Version: 18,
+		Name:    "user_room_versions",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&user0018{}, "Version"); err != nil {
+				return err
+			}
+			return tx.Migrator().AddColumn(&room0018{}, "Version")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&room0018{}, "Version"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&user0018{}, "Version"); err != nil {
+				return err
+			}
+			// SQLite rebuilds a table, and loses its indexes, to drop a
+			// column.
+			if !tx.Migrator().HasIndex(&room0018{}, "RoomTypeID") {
+				return tx.Migrator().CreateIndex(&room0018{}, "RoomTypeID")
+			}
+			return nil
+		},
+	})
+}
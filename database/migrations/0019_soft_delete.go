@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type user0019 struct {
+	DeletedAt *time.Time `gorm:"index"`
+}
+
+func (user0019) TableName() string { return "users" }
+
+type room0019 struct {
+	DeletedAt  *time.Time `gorm:"index"`
+	RoomTypeID uint       `gorm:"index"`
+}
+
+func (room0019) TableName() string { return "rooms" }
+
+type reservation0019 struct {
+	DeletedAt  *time.Time `gorm:"index"`
+	RoomTypeID uint       `gorm:"index"`
+}
+
+func (reservation0019) TableName() string { return "reservations" }
+
+func init() {
+	register(Migration{
+		Version: 19,
+		Name:    "soft_delete",
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&user0019{}, &room0019{}, &reservation0019{}} {
+				if err := tx.Migrator().AddColumn(model, "DeletedAt"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().CreateIndex(model, "DeletedAt"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			// Rows deleted in the meantime come back rather than being lost
+			// with the folios, invoices and payments that point at them.
+			// Reservations restored this way are not checked against the
+			// bookings made since.
+			for _, model := range []interface{}{&reservation0019{}, &room0019{}, &user0019{}} {
+				if err := tx.Migrator().DropIndex(model, "DeletedAt"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(model, "DeletedAt"); err != nil {
+					return err
+				}
+			}
+			// SQLite rebuilds a table, and loses its indexes, to drop a
+			// column.
+			for _, model := range []interface{}{&room0019{}, &reservation0019{}} {
+				if !tx.Migrator().HasIndex(model, "RoomTypeID") {
+					if err := tx.Migrator().CreateIndex(model, "RoomTypeID"); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	})
+}
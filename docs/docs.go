@@ -24,6 +24,87 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/availability": {
+            "get": {
+                "description": "Get the rooms that are in service and free for the whole date range, grouped by room type with the price of the dearest night and the total stay price of the type in minor units of its currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Search available rooms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (RFC3339 or YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (RFC3339 or YYYY-MM-DD)",
+                        "name": "end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Room type name",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of adults",
+                        "name": "guests",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of children",
+                        "name": "children",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price of any night of the stay, before taxes, in the base currency",
+                        "name": "max_price",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.AvailableRoomType"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing exchange rate",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/customers": {
             "get": {
                 "description": "Get a list of all users with the role of customer",
@@ -59,44 +140,77 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Login a user with username and password",
-                "consumes": [
-                    "application/json"
-                ],
+        "/exchange-rates": {
+            "get": {
+                "description": "Get the uploaded daily exchange rates, each the value of one unit of the currency in the base currency",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exchange Rates"
                 ],
-                "summary": "Login a user",
+                "summary": "Get exchange rates",
                 "parameters": [
                     {
-                        "description": "Username",
-                        "name": "username",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ExchangeRate"
+                            }
                         }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload daily exchange rates as a JSON array or as text/csv rows of currency,date (YYYY-MM-DD),rate. A rate is the value of one unit of the currency in the base currency and replaces any rate already uploaded for the same currency and day.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange Rates"
+                ],
+                "summary": "Upload exchange rates",
+                "parameters": [
                     {
-                        "description": "Password",
-                        "name": "password",
+                        "description": "Exchange rates",
+                        "name": "rates",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ExchangeRate"
+                            }
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Logged in successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ExchangeRate"
+                            }
                         }
                     },
                     "400": {
@@ -105,12 +219,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Invalid username or password",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -120,42 +228,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/occupancy": {
-            "post": {
-                "description": "Get the number of total, occupied, and available rooms in a given date range",
-                "consumes": [
-                    "application/json"
-                ],
+        "/housekeeping/board": {
+            "get": {
+                "description": "Get every room with its status and the housekeeping tasks outstanding on the given day",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Statistics"
+                    "Housekeeping"
                 ],
-                "summary": "Get hotel occupancy information",
+                "summary": "Get the housekeeping board",
                 "parameters": [
                     {
-                        "description": "Date range for occupancy check",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controllers.OccupancyInput"
-                        }
+                        "type": "string",
+                        "description": "Day (YYYY-MM-DD), defaults to today",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.BoardEntry"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid date",
                         "schema": {
                             "type": "string"
                         }
@@ -169,69 +271,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile": {
+        "/housekeeping/tasks": {
             "get": {
-                "description": "Get the profile information of the currently logged-in user",
+                "description": "Get every housekeeping task, optionally filtered by status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Housekeeping"
+                ],
+                "summary": "Get housekeeping tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task status (open, claimed, done)",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.HousekeepingTask"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/housekeeping/tasks/{task_id}/claim": {
             "put": {
-                "description": "Update the profile information of the currently logged-in user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Assign an open housekeeping task to the logged-in housekeeper",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Housekeeping"
                 ],
-                "summary": "Update user profile",
+                "summary": "Claim a housekeeping task",
                 "parameters": [
                     {
-                        "description": "Updated user data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.User"
-                        }
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "task_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.HousekeepingTask"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid task ID",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Task already claimed or done",
                         "schema": {
                             "type": "string"
                         }
@@ -245,48 +361,100 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile/password": {
+        "/housekeeping/tasks/{task_id}/complete": {
             "put": {
-                "description": "Update the password of the currently logged-in user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mark a housekeeping task as done and its room as clean and available. Admins may complete tasks claimed by someone else.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Housekeeping"
                 ],
-                "summary": "Update user password",
+                "summary": "Complete a housekeeping task",
                 "parameters": [
                     {
-                        "description": "Old and new passwords",
-                        "name": "password_data",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "task_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.HousekeepingTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid task ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Task claimed by someone else or done",
+                        "schema": {
+                            "type": "string"
                         }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{invoice_id}": {
+            "get": {
+                "description": "Get an issued invoice as JSON, or as a PDF document when format=pdf or the Accept header asks for application/pdf. Amounts are in minor units.",
+                "produces": [
+                    "application/json",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Invoice"
+                ],
+                "summary": "Get an invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoice_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or pdf",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Password updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Invoice"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid invoice ID",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Invoice not found",
                         "schema": {
                             "type": "string"
                         }
@@ -300,9 +468,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
+        "/login": {
             "post": {
-                "description": "Register a new user with username, password, email and role",
+                "description": "Login a user with username and password",
                 "consumes": [
                     "application/json"
                 ],
@@ -312,7 +480,7 @@ const docTemplate = `{
                 "tags": [
                     "User"
                 ],
-                "summary": "Register a new user",
+                "summary": "Login a user",
                 "parameters": [
                     {
                         "description": "Username",
@@ -331,29 +499,11 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Email",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Role",
-                        "name": "role",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User registered successfully",
+                    "200": {
+                        "description": "Logged in successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -364,37 +514,8 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/reservations": {
-            "get": {
-                "description": "Get a list of all reservations",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Reservation"
-                ],
-                "summary": "Get all reservations",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Reservation"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Reservations not found",
+                    "401": {
+                        "description": "Invalid username or password",
                         "schema": {
                             "type": "string"
                         }
@@ -406,9 +527,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/occupancy": {
             "post": {
-                "description": "Create a new reservation for a room",
+                "description": "Get the number of total, occupied, and available rooms in a given date range",
                 "consumes": [
                     "application/json"
                 ],
@@ -416,25 +539,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reservation"
+                    "Statistics"
                 ],
-                "summary": "Create a new reservation",
+                "summary": "Get hotel occupancy information",
                 "parameters": [
                     {
-                        "description": "Reservation data",
-                        "name": "reservation",
+                        "description": "Date range for occupancy check",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Reservation"
+                            "$ref": "#/definitions/controllers.OccupancyInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Reservation"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
                         }
                     },
                     "400": {
@@ -452,46 +578,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations/{reservation_id}": {
+        "/profile": {
             "get": {
-                "description": "Get details of a specific reservation",
+                "description": "Get the profile information of the currently logged-in user. The ETag header carries its version for conditional updates.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reservation"
-                ],
-                "summary": "Get reservation details",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Reservation ID",
-                        "name": "reservation_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Profile"
                 ],
+                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Reservation"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid reservation ID",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Reservation not found",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.User"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
@@ -499,7 +604,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing reservation",
+                "description": "Update the profile information of the currently logged-in user",
                 "consumes": [
                     "application/json"
                 ],
@@ -507,24 +612,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reservation"
+                    "Profile"
                 ],
-                "summary": "Update an existing reservation",
+                "summary": "Update user profile",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Reservation ID",
-                        "name": "reservation_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "ETag of the profile",
+                        "name": "If-Match",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Updated reservation data",
-                        "name": "reservation",
+                        "description": "Updated user data",
+                        "name": "user",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Reservation"
+                            "$ref": "#/definitions/models.User"
                         }
                     }
                 ],
@@ -532,7 +637,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Reservation"
+                            "$ref": "#/definitions/models.User"
                         }
                     },
                     "400": {
@@ -541,44 +646,20 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Reservation not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete a reservation by ID",
-                "tags": [
-                    "Reservation"
-                ],
-                "summary": "Delete a reservation",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Reservation ID",
-                        "name": "reservation_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "412": {
+                        "description": "Profile changed since it was read",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Reservation not found",
+                    "428": {
+                        "description": "If-Match header required",
                         "schema": {
                             "type": "string"
                         }
@@ -592,9 +673,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations/{reservation_id}/status": {
+        "/profile/password": {
             "put": {
-                "description": "Update the status of a reservation",
+                "description": "Update the password of the currently logged-in user",
                 "consumes": [
                     "application/json"
                 ],
@@ -602,32 +683,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reservation"
+                    "Profile"
                 ],
-                "summary": "Update reservation status",
+                "summary": "Update user password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Reservation ID",
-                        "name": "reservation_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "ETag of the profile",
+                        "name": "If-Match",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "description": "New status",
-                        "name": "status",
+                        "description": "Old and new passwords",
+                        "name": "password_data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Password updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.Reservation"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -636,8 +720,20 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Reservation not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Profile changed since it was read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
                         "schema": {
                             "type": "string"
                         }
@@ -651,23 +747,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/rooms": {
+        "/rate-overrides": {
             "get": {
-                "description": "Get a list of all rooms",
+                "description": "Get every seasonal and day-of-week nightly rate override",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Room"
+                    "Rates"
                 ],
-                "summary": "Get all rooms",
+                "summary": "Get all rate overrides",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Room"
+                                "$ref": "#/definitions/models.RateOverride"
                             }
                         }
                     },
@@ -680,7 +776,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new room with number, type, status, and price",
+                "description": "Replace the nightly price (minor units of the base currency) of the room type room_type_id between two dates and/or on some weekdays",
                 "consumes": [
                     "application/json"
                 ],
@@ -688,52 +784,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Room"
+                    "Rates"
                 ],
-                "summary": "Create a new room",
+                "summary": "Create a rate override",
                 "parameters": [
                     {
-                        "description": "Room Number",
-                        "name": "number",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Room Type",
-                        "name": "type",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Room Status",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Room Price",
-                        "name": "price",
+                        "description": "Rate override",
+                        "name": "override",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "number"
+                            "$ref": "#/definitions/models.RateOverride"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Room created successfully",
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.RateOverride"
                         }
                     },
                     "400": {
@@ -751,108 +820,100 @@ const docTemplate = `{
                 }
             }
         },
-        "/rooms/{room_id}": {
-            "get": {
-                "description": "Get details of a specific room",
-                "produces": [
-                    "application/json"
-                ],
+        "/rate-overrides/{override_id}": {
+            "delete": {
+                "description": "Delete a rate override by ID",
                 "tags": [
-                    "Room"
+                    "Rates"
                 ],
-                "summary": "Get room details",
+                "summary": "Delete a rate override",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Room ID",
-                        "name": "room_id",
+                        "description": "Rate override ID",
+                        "name": "override_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/models.Room"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid room ID",
+                        "description": "Invalid rate override ID",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Room not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing room with number, type, status, and price",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/rate-plans": {
+            "get": {
+                "description": "Get every rate plan with its percentage adjustment and nightly supplement (minor units)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Room"
+                    "Rates"
                 ],
-                "summary": "Update an existing room",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Room ID",
-                        "name": "room_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Room Number",
-                        "name": "number",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Room Type",
-                        "name": "type",
-                        "in": "body",
-                        "required": true,
+                "summary": "Get all rate plans",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RatePlan"
+                            }
                         }
                     },
-                    {
-                        "description": "Room Status",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "string"
                         }
-                    },
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a rate plan that adjusts the nightly price by a percentage and a fixed supplement in minor units of the base currency, with a deposit percentage, a free cancellation window in days before arrival and the cancellation policy applied after it (flexible, first-night, non-refundable)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rates"
+                ],
+                "summary": "Create a rate plan",
+                "parameters": [
                     {
-                        "description": "Room Price",
-                        "name": "price",
+                        "description": "Rate plan",
+                        "name": "plan",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "number"
+                            "$ref": "#/definitions/models.RatePlan"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Room updated successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.RatePlan"
                         }
                     },
                     "400": {
@@ -861,12 +922,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Room not found",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -874,31 +929,54 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a room by ID",
+            }
+        },
+        "/rate-plans/{plan_id}": {
+            "put": {
+                "description": "Update a rate plan. Reservations keep the prices they were booked at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Room"
+                    "Rates"
                 ],
-                "summary": "Delete a room",
+                "summary": "Update a rate plan",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Room ID",
-                        "name": "room_id",
+                        "description": "Rate plan ID",
+                        "name": "plan_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Rate plan",
+                        "name": "plan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RatePlan"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RatePlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Room not found",
+                        "description": "Rate plan not found",
                         "schema": {
                             "type": "string"
                         }
@@ -912,28 +990,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "get": {
-                "description": "Get a list of all users",
+        "/register": {
+            "post": {
+                "description": "Register a new user with username, password, email and role",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "User"
                 ],
-                "summary": "Get all users",
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Username",
+                        "name": "username",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Password",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Email",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "User registered successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Users not found",
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email taken by another or a deleted user",
                         "schema": {
                             "type": "string"
                         }
@@ -947,40 +1069,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{user_id}": {
+        "/reservations": {
             "get": {
-                "description": "Get details of a specific user by ID",
+                "description": "Get a list of all reservations",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
-                ],
-                "summary": "Get user details",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Reservation"
                 ],
+                "summary": "Get all reservations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid user id",
-                        "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Reservation"
+                            }
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Reservations not found",
                         "schema": {
                             "type": "string"
                         }
@@ -993,8 +1103,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update details of a specific user by ID",
+            "post": {
+                "description": "Create a new reservation for a room, given by room_number, or for any free room of a room type, given by its name in room_type. A room of the type is picked by the configured room assignment strategy, honoring the comma separated preferences (high-floor or low-floor, accessible) where it can. The stay is priced night by night as the room type for adults (default 1) and children (default 0) under the requested rate_plan (default \"standard\") and the breakdown is stored on the reservation. The party must fit within the maximum occupancy of the room type; guests beyond its base occupancy are charged the extra-person surcharge. When the plan requires a deposit it is charged to payment_source.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1002,42 +1112,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Reservation"
                 ],
-                "summary": "Update a user",
+                "summary": "Create a new reservation",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated user data",
-                        "name": "user",
+                        "description": "Reservation data",
+                        "name": "reservation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Reservation"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Reservation"
                         }
                     },
                     "400": {
-                        "description": "Invalid user id",
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "402": {
+                        "description": "Deposit declined or missing payment source",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Room, room type or user not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation dates conflict or no room of the type available",
                         "schema": {
                             "type": "string"
                         }
@@ -1049,37 +1164,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a user by ID",
+            }
+        },
+        "/reservations/{reservation_id}": {
+            "get": {
+                "description": "Get details of a specific reservation. The ETag header carries its version for conditional updates.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "User"
+                    "Reservation"
                 ],
-                "summary": "Delete a user",
+                "summary": "Get reservation details",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Reservation"
                         }
                     },
                     "400": {
-                        "description": "Invalid user id",
+                        "description": "Invalid reservation ID",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Reservation not found",
                         "schema": {
                             "type": "string"
                         }
@@ -1091,62 +1211,2829 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
+            },
+            "put": {
+                "description": "Update the room, dates, status, party or preferences of an existing reservation; the other fields of the reservation are read-only and ignored. Moving it to another room takes it out of the nightly room assignment optimization. The If-Match header must carry the ETag of the reservation as last read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Update an existing reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the reservation",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated reservation data",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.reservationUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation dates conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Reservation changed since it was read, also by the nightly room assignment optimization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a reservation by ID, which frees its room. It stays in the revenue reports and can be restored until it is purged.",
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Delete a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the reservation",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Reservation changed since it was read, also by the nightly room assignment optimization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change the start_date, end_date, adults, children or preferences of a pending, confirmed or checked-in reservation; any other field is refused. New dates are checked against the availability of the room and the stay is priced again; the folio of a checked-in stay is adjusted to the new price. The If-Match header must carry the ETag of the reservation as last read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Change some fields of a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the reservation",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.ReservationPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or field not editable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation dates conflict or reservation not editable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Reservation changed since it was read, also by the nightly room assignment optimization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/cancel": {
+            "post": {
+                "description": "Cancel a reservation, post the penalty of its rate plan's cancellation policy to the folio and refund the rest of what was paid. Amounts are in minor units.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Cancel a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.CancellationQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation cannot be cancelled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/cancel/preview": {
+            "get": {
+                "description": "Tell what cancelling a reservation now would cost under its rate plan's cancellation policy and how much would be refunded, without cancelling it. Amounts are in minor units.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Preview the cost of cancelling a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.CancellationQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation cannot be cancelled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/charges": {
+            "post": {
+                "description": "Post a debit (minibar, room-service, parking, late-checkout, ...) or credit (payment, adjustment) to the folio of a reservation. Amount is positive, in minor units.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folio"
+                ],
+                "summary": "Post a charge to a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Folio line",
+                        "name": "charge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FolioLine"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.FolioLine"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid charge",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/check-in": {
+            "post": {
+                "description": "Check in a confirmed reservation during its stay dates and mark its room as occupied",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Check in a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation cannot be checked in",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/check-out": {
+            "post": {
+                "description": "Check out a checked-in reservation and send its room to cleaning. The folio balance must be zero unless an admin passes override=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Check out a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check out despite an unsettled folio (admin only)",
+                        "name": "override",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can override the folio balance",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation cannot be checked out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/folio": {
+            "get": {
+                "description": "Get the charges and payments posted to a reservation with the running balance, in minor units",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folio"
+                ],
+                "summary": "Get the folio of a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Folio"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/history": {
+            "get": {
+                "description": "Get every status change and field edit of a reservation with the acting user, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Get reservation history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReservationEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/invoice": {
+            "get": {
+                "description": "Get the invoice issued when a reservation was checked out, as JSON or PDF like GET /invoices/{invoice_id}",
+                "produces": [
+                    "application/json",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Invoice"
+                ],
+                "summary": "Get the invoice of a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or pdf",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation or invoice not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/move": {
+            "post": {
+                "description": "Move a reservation to the room given by room_number. An upcoming stay moves as a whole. A checked-in guest moves now: the stay is split into segments per room, the room left goes to cleaning and the new room, which must be ready, becomes occupied. The new room must be free for the rest of the stay and fit the party. The price and room type of the stay do not change and each night stays attributed to the room it was spent in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Move a reservation to another room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Number of the new room",
+                        "name": "room_number",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or same room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation or room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation cannot move or room unavailable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/payments": {
+            "get": {
+                "description": "Get the deposits, payments and refunds of a reservation, oldest first. Amounts are in minor units.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get the payments of a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Payment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Charge an amount in minor units to a payment source through the payment gateway and credit it to the folio",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Take a payment for a reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "amount and payment source",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment declined",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/restore": {
+            "post": {
+                "description": "Bring back a deleted reservation that has not been purged yet. A pending, confirmed or checked-in reservation needs its room to still exist and be free.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Restore a deleted reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room was deleted or booked again",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/status": {
+            "put": {
+                "description": "Move a reservation through its lifecycle: pending -\u003e confirmed -\u003e checked-in -\u003e checked-out, with cancelled and no-show allowed from pending or confirmed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Update reservation status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the reservation",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation or user not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Reservation changed since it was read, also by the nightly room assignment optimization",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/revenue/daily": {
+            "post": {
+                "description": "Get the revenue of the nights sold on each day of a given date range, from the amounts stored on the reservations converted to the base currency at the rates of their booking dates, as total, net and tax",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get daily revenue for a date range",
+                "parameters": [
+                    {
+                        "description": "Date range for revenue calculation",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.GetDailyRevenue.RevenueInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "number"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing exchange rate",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/revenue/monthly": {
+            "post": {
+                "description": "Get the revenue of the nights sold in each month of a given date range, from the amounts stored on the reservations converted to the base currency at the rates of their booking dates, as total, net and tax",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get monthly revenue for a date range",
+                "parameters": [
+                    {
+                        "description": "Date range for revenue calculation",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.GetMonthlyRevenue.RevenueInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "number"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing exchange rate",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/revenue/total": {
+            "post": {
+                "description": "Get the total revenue of the hotel for a given date range, from the amounts stored on the reservations converted to the base currency at the rates of their booking dates, as total, net and tax",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get total revenue for a date range",
+                "parameters": [
+                    {
+                        "description": "Date range for revenue calculation",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.GetTotalRevenue.RevenueInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing exchange rate",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/room-assignments/optimize": {
+            "post": {
+                "description": "Run the nightly room assignment optimization now. Future reservations booked by room type, and not moved by hand since, are shuffled between the rooms of their type so that free nights stay together. Each move raises the version of the reservation, so an ETag read before no longer matches. Returns the reservations moved.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room Assignment"
+                ],
+                "summary": "Optimize room assignments",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.RoomMove"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/room-types": {
+            "get": {
+                "description": "Get the room type catalog with base prices in minor units of each type's currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Get all room types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RoomType"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a room type with a unique name, a description, a nightly base price (minor units of its currency, which defaults to the base currency), a maximum occupancy, the guests included in the price (default all of them), per-night surcharges for extra adults and children, beds, amenities and photo URLs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Create a room type",
+                "parameters": [
+                    {
+                        "description": "Room type",
+                        "name": "room_type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoomType"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.RoomType"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room type name already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/room-types/{room_type_id}": {
+            "get": {
+                "description": "Get a room type of the catalog by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Get a room type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room type ID",
+                        "name": "room_type_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RoomType"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid room type ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room type not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a room type. Every room of the type is sold at the new terms; reservations keep the prices they were booked at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Update a room type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room type ID",
+                        "name": "room_type_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Room type",
+                        "name": "room_type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoomType"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RoomType"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room type not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room type name already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a room type and its rate overrides. Types that rooms still belong to cannot be deleted.",
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Delete a room type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room type ID",
+                        "name": "room_type_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid room type ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room type not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room type still has rooms",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms": {
+            "get": {
+                "description": "Get a list of all rooms",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Get all rooms",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Room"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new room with number, room type and status. Price and capacity come from the room type.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Create a new room",
+                "parameters": [
+                    {
+                        "description": "Room Number",
+                        "name": "number",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Room type ID",
+                        "name": "RoomTypeID",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Room Status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Floor",
+                        "name": "Floor",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Whether the room is accessible",
+                        "name": "Accessible",
+                        "in": "body",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Room created successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room number taken by another or a deleted room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{room_id}": {
+            "get": {
+                "description": "Get details of a specific room. The ETag header carries its version for conditional updates.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Get room details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Room"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid room ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing room with number, room type and status. Price and capacity come from the room type.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Update an existing room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the room",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Room Number",
+                        "name": "number",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Room type ID",
+                        "name": "RoomTypeID",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Room Status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Floor",
+                        "name": "Floor",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Whether the room is accessible",
+                        "name": "Accessible",
+                        "in": "body",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Room updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room number taken by another or a deleted room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Room changed since it was read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a room by ID. Rooms with current or upcoming reservations cannot be deleted. A deleted room can be restored until it is purged.",
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Delete a room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the room",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room has current or upcoming reservations",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Room changed since it was read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{room_id}/restore": {
+            "post": {
+                "description": "Bring back a deleted room that has not been purged yet. Its room type must still exist.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Restore a deleted room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Room"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid room id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room type was deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/taxes": {
+            "get": {
+                "description": "Get every tax and fee, active or not",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxes"
+                ],
+                "summary": "Get all taxes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tax"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a tax charged on every night of new bookings: a percentage of the night (rate), a fixed amount per night or per guest per night (amount, minor units of the base currency), inclusive in or exclusive of the room price. Taxes are active unless created with active set to false.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxes"
+                ],
+                "summary": "Create a tax",
+                "parameters": [
+                    {
+                        "description": "Tax",
+                        "name": "tax",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Tax"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tax"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Tax code already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/taxes/{tax_id}": {
+            "put": {
+                "description": "Update a tax. Reservations keep the taxes they were priced with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxes"
+                ],
+                "summary": "Update a tax",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax ID",
+                        "name": "tax_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax",
+                        "name": "tax",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Tax"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tax"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tax not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Tax code already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a tax by ID. Reservations keep the taxes they were priced with.",
+                "tags": [
+                    "Taxes"
+                ],
+                "summary": "Delete a tax",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax ID",
+                        "name": "tax_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tax ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Get a list of all users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get all users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Users not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}": {
+            "get": {
+                "description": "Get details of a specific user by ID. The ETag header carries its version for conditional updates.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update details of a specific user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated user data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email taken by another or a deleted user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "User changed since it was read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a user by ID. A deleted user can be restored until it is purged.",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "User has current or upcoming reservations",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "User changed since it was read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/restore": {
+            "post": {
+                "description": "Bring back a deleted user that has not been purged yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Restore a deleted user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted user not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.GetDailyRevenue.RevenueInput": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.GetMonthlyRevenue.RevenueInput": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.GetTotalRevenue.RevenueInput": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
         "controllers.OccupancyInput": {
             "type": "object",
             "properties": {
                 "end_date": {
                     "type": "string"
                 },
-                "start_date": {
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.reservationUpdate": {
+            "type": "object",
+            "properties": {
+                "EndDate": {
+                    "type": "string"
+                },
+                "StartDate": {
+                    "type": "string"
+                },
+                "adults": {
+                    "type": "integer"
+                },
+                "children": {
+                    "type": "integer"
+                },
+                "preferences": {
+                    "type": "string"
+                },
+                "room_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Folio": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FolioLine"
+                    }
+                },
+                "reservation_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FolioLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "category": {
+                    "description": "room, minibar, room-service, parking, late-checkout, payment, refund, cancellation, adjustment",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "folio_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "posted_by": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "debit, credit",
+                    "type": "string"
+                }
+            }
+        },
+        "models.HousekeepingTask": {
+            "type": "object",
+            "properties": {
+                "assigned_to": {
+                    "type": "integer"
+                },
+                "claimed_at": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reservation_id": {
+                    "type": "integer"
+                },
+                "room_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "open, claimed, done",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Invoice": {
+            "type": "object",
+            "properties": {
+                "balance_due": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issued_by": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvoiceLine"
+                    }
+                },
+                "number": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "integer"
+                },
+                "reservation_id": {
+                    "type": "integer"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "tax_total": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.InvoiceLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "included": {
+                    "type": "boolean"
+                },
+                "invoice_id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "night, extra, tax, payment, refund",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "deposit, payment, refund",
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "refund_of": {
+                    "type": "integer"
+                },
+                "reservation_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "captured, refunded, failed",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RateOverride": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "room_type_id": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weekdays": {
+                    "description": "comma separated: mon,tue,wed,thu,fri,sat,sun",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RatePlan": {
+            "type": "object",
+            "properties": {
+                "cancellation_policy": {
+                    "description": "flexible, first-night, non-refundable",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "standard, non-refundable, breakfast-included",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit_percent": {
+                    "type": "number"
+                },
+                "free_cancellation_days": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nightly_supplement": {
+                    "type": "integer"
+                },
+                "percent_adjustment": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Reservation": {
+            "type": "object",
+            "properties": {
+                "adults": {
+                    "type": "integer"
+                },
+                "auto_assigned": {
+                    "description": "AutoAssigned is set when the room was picked by the system for a\nbooking by room type, which lets it move the stay to another room of\nthe type until arrival. Preferences is a comma separated list of\nhigh-floor, low-floor and accessible considered when picking.",
+                    "type": "boolean"
+                },
+                "children": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Currency is an ISO 4217 code and TotalAmount the price of the stay in\nits minor units, both fixed when the stay is priced. TotalAmount\nincludes every tax, of which TaxAmount is the sum.",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt marks a deleted reservation. It is kept, with its pricing\nand history, until the nightly purge removes it.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gorm.DeletedAt"
+                        }
+                    ]
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "nights": {
+                    "description": "Nights is the per-night price breakdown computed at booking time and\nTaxes the taxes charged on each of those nights. Segments is only set\nonce a guest has moved rooms during the stay: it then splits the stay\nin the rooms used, in order, and RoomID is the room of the last one.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReservationNight"
+                    }
+                },
+                "preferences": {
+                    "type": "string"
+                },
+                "rate_plan_id": {
+                    "type": "integer"
+                },
+                "room_id": {
+                    "type": "integer"
+                },
+                "room_type_id": {
+                    "description": "RoomTypeID is the type of the room, which the stay is sold and priced\nas.",
+                    "type": "integer"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReservationSegment"
+                    }
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, confirmed, checked-in, checked-out, cancelled, no-show",
+                    "type": "string"
+                },
+                "status_updated_at": {
+                    "type": "string"
+                },
+                "status_updated_by": {
+                    "description": "StatusUpdatedBy and StatusUpdatedAt record the user behind the last\nstatus transition.",
+                    "type": "integer"
+                },
+                "tax_amount": {
+                    "type": "integer"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReservationTax"
+                    }
+                },
+                "total_amount": {
+                    "type": "integer"
+                },
+                "updatedAt": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Version counts the saved changes to the reservation. It is sent as\nits ETag and has to match for a conditional update to go through.",
+                    "type": "integer"
                 }
             }
         },
-        "gorm.DeletedAt": {
+        "models.ReservationEvent": {
             "type": "object",
             "properties": {
-                "time": {
+                "created_at": {
                     "type": "string"
                 },
-                "valid": {
-                    "description": "Valid is true if Time is not NULL",
-                    "type": "boolean"
+                "field": {
+                    "description": "status, user_id, room_id, start_date, end_date, adults, children, preferences, rate_plan_id, total_amount",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "reservation_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Reservation": {
+        "models.ReservationNight": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "amount": {
+                    "type": "integer"
+                },
+                "base_amount": {
+                    "type": "integer"
+                },
+                "date": {
                     "type": "string"
                 },
-                "deleted_at": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "extra_person_amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reservation_id": {
+                    "type": "integer"
                 },
+                "room_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ReservationSegment": {
+            "type": "object",
+            "properties": {
                 "end_date": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "reservation_id": {
+                    "type": "integer"
+                },
                 "room_id": {
                     "type": "integer"
                 },
                 "start_date": {
                     "type": "string"
+                }
+            }
+        },
+        "models.ReservationTax": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
                 },
-                "status": {
-                    "description": "pending, confirmed, checked-in, checked-out, cancelled, no-show",
+                "date": {
                     "type": "string"
                 },
-                "updated_at": {
+                "id": {
+                    "type": "integer"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
                 },
-                "user_id": {
+                "reservation_id": {
+                    "type": "integer"
+                },
+                "tax_id": {
                     "type": "integer"
                 }
             }
@@ -1154,11 +4041,22 @@ const docTemplate = `{
         "models.Room": {
             "type": "object",
             "properties": {
+                "accessible": {
+                    "type": "boolean"
+                },
                 "createdAt": {
                     "type": "string"
                 },
                 "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                    "description": "DeletedAt marks a deleted room, like Reservation.DeletedAt.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gorm.DeletedAt"
+                        }
+                    ]
+                },
+                "floor": {
+                    "type": "integer"
                 },
                 "id": {
                     "type": "integer"
@@ -1166,18 +4064,110 @@ const docTemplate = `{
                 "number": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "roomTypeID": {
+                    "type": "integer"
                 },
                 "status": {
-                    "description": "\"available\", \"occupied\", \"cleaning\"",
+                    "description": "\"available\", \"occupied\", \"cleaning\", \"out-of-service\"",
                     "type": "string"
                 },
-                "type": {
-                    "description": "\"single\", \"double\", \"suite\"",
+                "updateAt": {
                     "type": "string"
                 },
-                "updateAt": {
+                "version": {
+                    "description": "Version counts the saved changes to the room, like Reservation.Version.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RoomType": {
+            "type": "object",
+            "properties": {
+                "amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "base_occupancy": {
+                    "type": "integer"
+                },
+                "base_price": {
+                    "type": "integer"
+                },
+                "beds": {
+                    "description": "e.g. \"1 king\", \"2 twin + sofa bed\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217 code",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "extra_adult_amount": {
+                    "type": "integer"
+                },
+                "extra_child_amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_occupancy": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "photos": {
+                    "description": "image URLs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Tax": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "percentage, per-night, per-guest-night",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
@@ -1189,7 +4179,12 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                    "description": "DeletedAt marks a deleted user, like Reservation.DeletedAt.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gorm.DeletedAt"
+                        }
+                    ]
                 },
                 "email": {
                     "type": "string"
@@ -1201,7 +4196,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "role": {
-                    "description": "\"admin\", \"receptionist\", \"customer\"",
+                    "description": "\"admin\", \"receptionist\", \"housekeeper\", \"customer\"",
                     "type": "string"
                 },
                 "updatedAt": {
@@ -1209,6 +4204,152 @@ const docTemplate = `{
                 },
                 "username": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "Version counts the saved changes to the user, like Reservation.Version.",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.AvailableRoom": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.AvailableRoomType": {
+            "type": "object",
+            "properties": {
+                "amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "beds": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "max_occupancy": {
+                    "type": "integer"
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AvailableRoom"
+                    }
+                },
+                "total_price": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.BoardEntry": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "type": "string"
+                },
+                "room_id": {
+                    "type": "integer"
+                },
+                "room_status": {
+                    "type": "string"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HousekeepingTask"
+                    }
+                }
+            }
+        },
+        "service.CancellationQuote": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "free_until": {
+                    "description": "FreeUntil is the moment cancelling stops being free, when the rate\nplan has a free cancellation window.",
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "integer"
+                },
+                "penalty": {
+                    "type": "integer"
+                },
+                "policy": {
+                    "type": "string"
+                },
+                "refund": {
+                    "type": "integer"
+                },
+                "reservation_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.ReservationPatch": {
+            "type": "object",
+            "properties": {
+                "adults": {
+                    "type": "integer"
+                },
+                "children": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "preferences": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.RoomMove": {
+            "type": "object",
+            "properties": {
+                "from_room_id": {
+                    "type": "integer"
+                },
+                "reservation_id": {
+                    "type": "integer"
+                },
+                "to_room_id": {
+                    "type": "integer"
                 }
             }
         }
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"hotel_management_system/controllers"
 	"hotel_management_system/database"
+	"hotel_management_system/repository"
 	"hotel_management_system/routes"
+	service "hotel_management_system/services"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/joho/godotenv"
 )
@@ -30,9 +34,48 @@ func main() {
 	}
 
 	database.Connect()
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
 	database.Migrate()
 
-	r := routes.InitRouter()
+	assignment, err := service.ConfiguredAssignmentStrategy()
+	if err != nil {
+		log.Fatal("Unknown ROOM_ASSIGNMENT_STRATEGY: ", os.Getenv("ROOM_ASSIGNMENT_STRATEGY"))
+	}
+	retention, err := service.ConfiguredDeletedRetention()
+	if err != nil {
+		log.Fatal("Invalid DELETED_RETENTION_DAYS: ", os.Getenv("DELETED_RETENTION_DAYS"))
+	}
+	store := repository.NewGormStore(database.DB)
+
+	// Rooms of bookings made by room type are shuffled every night so that
+	// free nights stay together.
+	go service.RunNightly("room assignment", func() error {
+		moves, err := service.OptimizeRoomAssignments(store, assignment)
+		if err == nil {
+			log.Printf("Room assignment moved %d reservations", len(moves))
+		}
+		return err
+	})
+
+	// Deleted users, rooms and reservations can be restored until they are
+	// past their retention and purged.
+	go service.RunNightly("purge deleted", func() error {
+		purged, err := service.PurgeDeleted(store, retention)
+		if err == nil {
+			log.Printf("Purged %d reservations, %d rooms and %d users", purged.Reservations, purged.Rooms, purged.Users)
+		}
+		return err
+	})
+
+	// No card processor is integrated yet; the fake gateway accepts every
+	// payment source except service.DeclinedSource.
+	h := controllers.NewHandler(store, service.NewFakeGateway(), assignment)
+	r := routes.InitRouter(h)
 
 	log.Println("Server started on port 8080")
 	log.Fatal(http.ListenAndServe(":8080", r))
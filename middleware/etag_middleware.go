@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// VersionFunc returns the current version of the resource a request is for.
+// An error, e.g. for a resource that does not exist, is left to the handler
+// to report.
+type VersionFunc func(r *http.Request) (uint, error)
+
+// ETagOf formats a version as a strong entity tag.
+func ETagOf(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ETag sets the ETag header of the response to the version of the resource.
+func ETag(version VersionFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if current, err := version(r); err == nil {
+				w.Header().Set("ETag", ETagOf(current))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IfMatch lets a request through only when its If-Match header names the
+// current version of the resource, or is "*". It answers 428 when the
+// header is missing and 412 when it names another version. The handler
+// reads the matched version with ExpectedVersion and saves against it, so
+// that a change made in between is caught as well.
+func IfMatch(version VersionFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := strings.TrimSpace(r.Header.Get("If-Match"))
+			if header == "" {
+				http.Error(w, "If-Match header required.", http.StatusPreconditionRequired)
+				return
+			}
+
+			current, err := version(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !matches(header, current) {
+				http.Error(w, "Resource was changed by someone else; reload it and try again.", http.StatusPreconditionFailed)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "version", current)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ExpectedVersion returns the version IfMatch matched for r.
+func ExpectedVersion(r *http.Request) uint {
+	version, _ := r.Context().Value("version").(uint)
+	return version
+}
+
+func matches(header string, current uint) bool {
+	if header == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimSpace(tag) == ETagOf(current) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// atVersion is a VersionFunc of a resource at version 3, or of a missing one
+// when the request asks for /missing.
+func atVersion(r *http.Request) (uint, error) {
+	if r.URL.Path == "/missing" {
+		return 0, errors.New("not found")
+	}
+	return 3, nil
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		ifMatch string
+		want    int
+		version uint
+	}{
+		{name: "missing header", path: "/", want: http.StatusPreconditionRequired},
+		{name: "current version", path: "/", ifMatch: `"3"`, want: http.StatusOK, version: 3},
+		{name: "stale version", path: "/", ifMatch: `"2"`, want: http.StatusPreconditionFailed},
+		{name: "weak tag", path: "/", ifMatch: `W/"3"`, want: http.StatusPreconditionFailed},
+		{name: "one of several", path: "/", ifMatch: `"1", "3"`, want: http.StatusOK, version: 3},
+		{name: "any version", path: "/", ifMatch: "*", want: http.StatusOK, version: 3},
+		{name: "missing resource left to the handler", path: "/missing", ifMatch: `"3"`, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var version uint
+			handler := IfMatch(atVersion)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				version = ExpectedVersion(r)
+			}))
+			r := httptest.NewRequest(http.MethodPut, tt.path, nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.want || version != tt.version {
+				t.Errorf("got %d at version %d, want %d at %d", w.Code, version, tt.want, tt.version)
+			}
+		})
+	}
+}
+
+func TestETag(t *testing.T) {
+	handler := ETag(atVersion)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for path, want := range map[string]string{"/": `"3"`, "/missing": ""} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if etag := w.Header().Get("ETag"); etag != want {
+			t.Errorf("%s: got ETag %q, want %q", path, etag, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"hotel_management_system/database"
+	"hotel_management_system/database/migrations"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+const migrateUsage = "usage: hotel_management_system migrate up|down [steps]|status"
+
+// runMigrate implements the migrate subcommand.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migrations.Up(database.DB); err != nil {
+			log.Fatal("Failed to apply migrations: ", err)
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatal("Invalid number of steps: ", args[1])
+			}
+			steps = n
+		}
+		if err := migrations.Down(database.DB, steps); err != nil {
+			log.Fatal("Failed to roll back migrations: ", err)
+		}
+	case "status":
+		statuses, err := migrations.List(database.DB)
+		if err != nil {
+			log.Fatal("Failed to read migration status: ", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(tw, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		tw.Flush()
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// ExchangeRate is what one unit of Currency was worth in the property base
+// currency on Date. Rates are uploaded per day; a conversion uses the latest
+// rate on or before the day it needs.
+type ExchangeRate struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Currency  string    `gorm:"size:3;not null;uniqueIndex:idx_exchange_rates_currency_date" json:"currency"`
+	Date      time.Time `gorm:"not null;uniqueIndex:idx_exchange_rates_currency_date" json:"date"`
+	Rate      float64   `gorm:"not null" json:"rate"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
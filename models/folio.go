@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// Folio is the guest account of a reservation. Balance is what the guest
+// owes in minor units of Currency: debits raise it and credits lower it.
+type Folio struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	ReservationID uint        `gorm:"not null;uniqueIndex" json:"reservation_id"`
+	Currency      string      `gorm:"size:3" json:"currency"`
+	Balance       int64       `json:"balance"`
+	Lines         []FolioLine `gorm:"foreignKey:FolioID" json:"lines"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// FolioLine is a single debit or credit posted to a folio. Amount is always
+// positive; Balance is the running balance of the folio after the line.
+type FolioLine struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	FolioID     uint      `gorm:"not null;index" json:"folio_id"`
+	Type        string    `gorm:"not null" json:"type"`     //debit, credit
+	Category    string    `gorm:"not null" json:"category"` //room, minibar, room-service, parking, late-checkout, payment, refund, cancellation, adjustment
+	Description string    `json:"description"`
+	Amount      int64     `gorm:"not null" json:"amount"`
+	Balance     int64     `json:"balance"`
+	PostedBy    uint      `json:"posted_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
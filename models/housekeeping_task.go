@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// HousekeepingTask is a room that needs cleaning, typically created when a
+// guest checks out.
+type HousekeepingTask struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	RoomID        uint       `gorm:"not null;index" json:"room_id"`
+	ReservationID uint       `json:"reservation_id"`
+	Status        string     `gorm:"not null" json:"status"` //open, claimed, done
+	AssignedTo    uint       `json:"assigned_to"`
+	ClaimedAt     *time.Time `json:"claimed_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// Invoice is the bill issued for a reservation at check-out. Invoices are
+// immutable once issued and numbered sequentially per year as YYYY-NNNNNN.
+// Amounts are in minor units of Currency.
+type Invoice struct {
+	ID            uint          `gorm:"primaryKey" json:"id"`
+	Number        string        `gorm:"not null;uniqueIndex" json:"number"`
+	Year          int           `gorm:"not null" json:"year"`
+	Sequence      int           `gorm:"not null" json:"sequence"`
+	ReservationID uint          `gorm:"not null;uniqueIndex" json:"reservation_id"`
+	UserID        uint          `json:"user_id"`
+	Currency      string        `gorm:"size:3" json:"currency"`
+	Subtotal      int64         `json:"subtotal"`
+	TaxTotal      int64         `json:"tax_total"`
+	Total         int64         `json:"total"`
+	Paid          int64         `json:"paid"`
+	BalanceDue    int64         `json:"balance_due"`
+	Lines         []InvoiceLine `gorm:"foreignKey:InvoiceID" json:"lines"`
+	IssuedBy      uint          `json:"issued_by"`
+	IssuedAt      time.Time     `json:"issued_at"`
+}
+
+// InvoiceLine is a night, extra charge, tax, payment or refund printed on an
+// invoice. Included marks a tax that is already part of the night amounts.
+type InvoiceLine struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	InvoiceID   uint       `gorm:"not null;index" json:"invoice_id"`
+	Kind        string     `gorm:"not null" json:"kind"` //night, extra, tax, payment, refund
+	Description string     `json:"description"`
+	Date        *time.Time `json:"date,omitempty"`
+	Amount      int64      `json:"amount"`
+	Included    bool       `json:"included"`
+}
+
+// InvoiceSequence holds the last invoice number issued in Year.
+type InvoiceSequence struct {
+	Year       int `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int `gorm:"not null"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// Payment is money taken from or returned to a guest through the payment
+// gateway for a reservation. Refunds are separate rows pointing at the
+// payment they return money from. Amounts are in minor units of Currency.
+type Payment struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ReservationID uint      `gorm:"not null;index" json:"reservation_id"`
+	Kind          string    `gorm:"not null" json:"kind"`   //deposit, payment, refund
+	Status        string    `gorm:"not null" json:"status"` //captured, refunded, failed
+	Amount        int64     `gorm:"not null" json:"amount"`
+	Currency      string    `gorm:"size:3" json:"currency"`
+	Reference     string    `json:"reference"`
+	RefundOf      uint      `json:"refund_of,omitempty"`
+	CreatedBy     uint      `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
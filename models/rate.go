@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// RatePlan adjusts the nightly room price for a booking condition such as
+// non-refundable or breakfast included. Amounts are in minor units of the
+// base currency.
+// DepositPercent of the stay total is taken when booking. Cancelling is free
+// until FreeCancellationDays before arrival (0 means never); afterwards the
+// CancellationPolicy decides the penalty.
+type RatePlan struct {
+	ID                   uint      `gorm:"primaryKey" json:"id"`
+	Code                 string    `gorm:"unique;not null" json:"code"` //standard, non-refundable, breakfast-included
+	Name                 string    `gorm:"not null" json:"name"`
+	PercentAdjustment    float64   `json:"percent_adjustment"`
+	NightlySupplement    int64     `json:"nightly_supplement"`
+	DepositPercent       float64   `gorm:"not null;default:0" json:"deposit_percent"`
+	CancellationPolicy   string    `gorm:"not null;default:flexible" json:"cancellation_policy"` //flexible, first-night, non-refundable
+	FreeCancellationDays int       `gorm:"not null;default:0" json:"free_cancellation_days"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// RateOverride replaces the nightly price of every room of a room type on the
+// nights it matches: between StartDate and EndDate (inclusive) when they are
+// set, and on the listed Weekdays when they are set. Amount is in minor units
+// of the base currency.
+type RateOverride struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	RoomTypeID uint       `gorm:"not null;index" json:"room_type_id"`
+	StartDate  *time.Time `json:"start_date"`
+	EndDate    *time.Time `json:"end_date"`
+	Weekdays   string     `json:"weekdays"` //comma separated: mon,tue,wed,thu,fri,sat,sun
+	Amount     int64      `gorm:"not null" json:"amount"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// ReservationNight is the price of one night of a reservation in its
+// currency, fixed when the reservation is priced so that later rate changes
+// do not rewrite it. Amount includes ExtraPersonAmount, the surcharge for
+// guests beyond the base occupancy of the room. RoomID is the room the night
+// was spent in, which only differs between nights when the guest moved.
+type ReservationNight struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	ReservationID     uint      `gorm:"not null;index" json:"reservation_id"`
+	RoomID            uint      `gorm:"not null;default:0;index" json:"room_id"`
+	Date              time.Time `gorm:"not null" json:"date"`
+	BaseAmount        int64     `json:"base_amount"`
+	ExtraPersonAmount int64     `gorm:"not null;default:0" json:"extra_person_amount"`
+	Amount            int64     `gorm:"not null" json:"amount"`
+}
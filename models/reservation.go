@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Reservation struct {
@@ -11,6 +13,51 @@ type Reservation struct {
 	StartDate time.Time
 	EndDate   time.Time
 	Status    string `gorm:"string" json:"status"` //pending, confirmed, checked-in, checked-out, cancelled, no-show
+	Adults    int    `gorm:"not null;default:1" json:"adults"`
+	Children  int    `gorm:"not null;default:0" json:"children"`
+	// StatusUpdatedBy and StatusUpdatedAt record the user behind the last
+	// status transition.
+	StatusUpdatedBy uint       `json:"status_updated_by"`
+	StatusUpdatedAt *time.Time `json:"status_updated_at"`
+	RatePlanID      uint       `json:"rate_plan_id"`
+	// RoomTypeID is the type of the room, which the stay is sold and priced
+	// as.
+	RoomTypeID uint `gorm:"not null;default:0;index" json:"room_type_id"`
+	// AutoAssigned is set when the room was picked by the system for a
+	// booking by room type, which lets it move the stay to another room of
+	// the type until arrival. Preferences is a comma separated list of
+	// high-floor, low-floor and accessible considered when picking.
+	AutoAssigned bool   `gorm:"not null;default:false" json:"auto_assigned"`
+	Preferences  string `json:"preferences"`
+	// Currency is an ISO 4217 code and TotalAmount the price of the stay in
+	// its minor units, both fixed when the stay is priced. TotalAmount
+	// includes every tax, of which TaxAmount is the sum.
+	Currency    string `gorm:"size:3" json:"currency"`
+	TotalAmount int64  `json:"total_amount"`
+	TaxAmount   int64  `json:"tax_amount"`
+	// Nights is the per-night price breakdown computed at booking time and
+	// Taxes the taxes charged on each of those nights. Segments is only set
+	// once a guest has moved rooms during the stay: it then splits the stay
+	// in the rooms used, in order, and RoomID is the room of the last one.
+	Nights    []ReservationNight   `gorm:"foreignKey:ReservationID" json:"nights,omitempty"`
+	Taxes     []ReservationTax     `gorm:"foreignKey:ReservationID" json:"taxes,omitempty"`
+	Segments  []ReservationSegment `gorm:"foreignKey:ReservationID" json:"segments,omitempty"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// Version counts the saved changes to the reservation. It is sent as
+	// its ETag and has to match for a conditional update to go through.
+	Version uint `gorm:"not null;default:1" json:"version"`
+	// DeletedAt marks a deleted reservation. It is kept, with its pricing
+	// and history, until the nightly purge removes it.
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// ReservationSegment is the part of a stay, from StartDate to EndDate, spent
+// in one room.
+type ReservationSegment struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ReservationID uint      `gorm:"not null;index" json:"reservation_id"`
+	RoomID        uint      `gorm:"not null;index" json:"room_id"`
+	StartDate     time.Time `gorm:"not null" json:"start_date"`
+	EndDate       time.Time `gorm:"not null" json:"end_date"`
 }
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// ReservationEvent records a single change to a reservation: its creation,
+// a status transition or an edited field, together with the acting user.
+type ReservationEvent struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ReservationID uint      `gorm:"not null;index" json:"reservation_id"`
+	UserID        uint      `json:"user_id"`
+	Field         string    `gorm:"not null" json:"field"` //status, user_id, room_id, start_date, end_date, adults, children, preferences, rate_plan_id, total_amount
+	OldValue      string    `json:"old_value"`
+	NewValue      string    `json:"new_value"`
+	CreatedAt     time.Time `json:"created_at"`
+}
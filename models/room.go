@@ -2,14 +2,25 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// RoomOutOfService marks a room that cannot be booked, e.g. during maintenance.
+const RoomOutOfService = "out-of-service"
+
+// Room is a physical room. Its price and capacity come from its RoomType.
 type Room struct {
-	ID        uint    `gorm:"primaryKey"`
-	Number    string  `gorm:"unique;not null"`
-	Type      string  `gorm:"not null"` //"single", "double", "suite"
-	Status    string  `gorm:"not null"` //"available", "occupied", "cleaning"
-	Price     float64 `gorm:"not null"`
-	CreatedAt time.Time
-	UpdateAt  time.Time
+	ID         uint   `gorm:"primaryKey"`
+	Number     string `gorm:"unique;not null"`
+	RoomTypeID uint   `gorm:"not null;index"`
+	Status     string `gorm:"not null"` //"available", "occupied", "cleaning", "out-of-service"
+	Floor      int    `gorm:"not null;default:0"`
+	Accessible bool   `gorm:"not null;default:false"`
+	CreatedAt  time.Time
+	UpdateAt   time.Time
+	// Version counts the saved changes to the room, like Reservation.Version.
+	Version uint `gorm:"not null;default:1"`
+	// DeletedAt marks a deleted room, like Reservation.DeletedAt.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
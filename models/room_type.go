@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// RoomType is a category of interchangeable rooms, such as "double", that
+// guests book without picking a room number. Every room of the type is sold
+// at BasePrice (minor units of Currency) per night for up to BaseOccupancy
+// guests; every further adult or child up to MaxOccupancy adds
+// ExtraAdultAmount or ExtraChildAmount per night.
+type RoomType struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	Name             string    `gorm:"unique;not null" json:"name"`
+	Description      string    `json:"description"`
+	BasePrice        int64     `gorm:"not null" json:"base_price"`
+	Currency         string    `gorm:"size:3;not null" json:"currency"` //ISO 4217 code
+	MaxOccupancy     int       `gorm:"not null;default:1" json:"max_occupancy"`
+	BaseOccupancy    int       `gorm:"not null;default:1" json:"base_occupancy"`
+	Beds             string    `json:"beds"` //e.g. "1 king", "2 twin + sofa bed"
+	ExtraAdultAmount int64     `gorm:"not null;default:0" json:"extra_adult_amount"`
+	ExtraChildAmount int64     `gorm:"not null;default:0" json:"extra_child_amount"`
+	Amenities        []string  `gorm:"type:text;serializer:json" json:"amenities"`
+	Photos           []string  `gorm:"type:text;serializer:json" json:"photos"` //image URLs
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
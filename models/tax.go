@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Tax is a tax or fee charged on accommodation. Percentage taxes use Rate
+// (e.g. 10 for 10%); fixed ones charge Amount, in minor units of the base
+// currency, per night or per guest per night. An inclusive tax is already
+// part of the room price, an exclusive one is added on top of it.
+type Tax struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Code      string    `gorm:"unique;not null" json:"code"`
+	Name      string    `gorm:"not null" json:"name"`
+	Kind      string    `gorm:"not null" json:"kind"` //percentage, per-night, per-guest-night
+	Rate      float64   `json:"rate"`
+	Amount    int64     `json:"amount"`
+	Inclusive bool      `json:"inclusive"`
+	Active    bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReservationTax is the amount of a tax charged for one night of a
+// reservation, fixed when the stay is priced.
+type ReservationTax struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ReservationID uint      `gorm:"not null;index" json:"reservation_id"`
+	TaxID         uint      `json:"tax_id"`
+	Name          string    `json:"name"`
+	Date          time.Time `gorm:"not null" json:"date"`
+	Amount        int64     `gorm:"not null" json:"amount"`
+	Inclusive     bool      `json:"inclusive"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"gorm.io/gorm"
 )
 
 type User struct {
@@ -11,9 +12,13 @@ type User struct {
 	Username  string `gorm:"unique;not null"`
 	Password  string `gorm:"not null"`
 	Email     string `gorm:"unique; not null"`
-	Role      string `gorm:"not null"` //"admin", "receptionist", "customer"
+	Role      string `gorm:"not null"` //"admin", "receptionist", "housekeeper", "customer"
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// Version counts the saved changes to the user, like Reservation.Version.
+	Version uint `gorm:"not null;default:1"`
+	// DeletedAt marks a deleted user, like Reservation.DeletedAt.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 type Claims struct {
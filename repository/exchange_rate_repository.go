@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExchangeRateRepository stores the daily exchange rates to the base
+// currency.
+type ExchangeRateRepository interface {
+	// Upsert stores rates, replacing any rate already stored for the same
+	// currency and day.
+	Upsert(rates []models.ExchangeRate) error
+	// FindAll returns the rates of currency, or of every currency when it is
+	// empty, ordered by currency and date.
+	FindAll(currency string) ([]models.ExchangeRate, error)
+	// FindLatest returns the most recent rate of currency on or before date.
+	FindLatest(currency string, date time.Time) (*models.ExchangeRate, error)
+}
+
+type gormExchangeRateRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormExchangeRateRepository) Upsert(rates []models.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(&rates).Error
+}
+
+func (r *gormExchangeRateRepository) FindAll(currency string) ([]models.ExchangeRate, error) {
+	query := r.db.Order("currency, date")
+	if currency != "" {
+		query = query.Where("currency = ?", currency)
+	}
+	var rates []models.ExchangeRate
+	if err := query.Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (r *gormExchangeRateRepository) FindLatest(currency string, date time.Time) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	if err := r.db.Where("currency = ? AND date <= ?", currency, date).Order("date desc").First(&rate).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &rate, nil
+}
+
+type memoryExchangeRateRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryExchangeRateRepository) Upsert(rates []models.ExchangeRate) error {
+	defer r.db.lock()()
+	for i := range rates {
+		for _, existing := range r.db.exchangeRates.all() {
+			if existing.Currency == rates[i].Currency && existing.Date.Equal(rates[i].Date) {
+				rates[i].ID = existing.ID
+				rates[i].CreatedAt = existing.CreatedAt
+			}
+		}
+		if rates[i].ID == 0 {
+			r.db.exchangeRates.insert(&rates[i].ID, &rates[i])
+		} else {
+			r.db.exchangeRates.put(rates[i].ID, rates[i])
+		}
+	}
+	return nil
+}
+
+func (r *memoryExchangeRateRepository) FindAll(currency string) ([]models.ExchangeRate, error) {
+	defer r.db.lock()()
+	var rates []models.ExchangeRate
+	for _, rate := range r.db.exchangeRates.all() {
+		if currency == "" || rate.Currency == currency {
+			rates = append(rates, rate)
+		}
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Currency != rates[j].Currency {
+			return rates[i].Currency < rates[j].Currency
+		}
+		return rates[i].Date.Before(rates[j].Date)
+	})
+	return rates, nil
+}
+
+func (r *memoryExchangeRateRepository) FindLatest(currency string, date time.Time) (*models.ExchangeRate, error) {
+	defer r.db.lock()()
+	var latest *models.ExchangeRate
+	for _, rate := range r.db.exchangeRates.all() {
+		if rate.Currency != currency || rate.Date.After(date) {
+			continue
+		}
+		if latest == nil || rate.Date.After(latest.Date) {
+			rate := rate
+			latest = &rate
+		}
+	}
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+	return latest, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FolioRepository stores folios and their lines. Folios are loaded without
+// lines; FindLines returns them oldest first.
+type FolioRepository interface {
+	Create(folio *models.Folio) error
+	FindByReservation(reservationID uint) (*models.Folio, error)
+	// FindByReservationForUpdate loads the folio of a reservation and locks
+	// its row until the surrounding transaction ends.
+	FindByReservationForUpdate(reservationID uint) (*models.Folio, error)
+	Save(folio *models.Folio) error
+	CreateLine(line *models.FolioLine) error
+	FindLines(folioID uint) ([]models.FolioLine, error)
+}
+
+type gormFolioRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormFolioRepository) Create(folio *models.Folio) error {
+	return r.db.Omit(clause.Associations).Create(folio).Error
+}
+
+func (r *gormFolioRepository) FindByReservation(reservationID uint) (*models.Folio, error) {
+	var folio models.Folio
+	if err := r.db.Where("reservation_id = ?", reservationID).First(&folio).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &folio, nil
+}
+
+func (r *gormFolioRepository) FindByReservationForUpdate(reservationID uint) (*models.Folio, error) {
+	var folio models.Folio
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("reservation_id = ?", reservationID).First(&folio).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &folio, nil
+}
+
+func (r *gormFolioRepository) Save(folio *models.Folio) error {
+	return r.db.Omit(clause.Associations).Save(folio).Error
+}
+
+func (r *gormFolioRepository) CreateLine(line *models.FolioLine) error {
+	return r.db.Create(line).Error
+}
+
+func (r *gormFolioRepository) FindLines(folioID uint) ([]models.FolioLine, error) {
+	var lines []models.FolioLine
+	if err := r.db.Where("folio_id = ?", folioID).Order("id").Find(&lines).Error; err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+type memoryFolioRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryFolioRepository) Create(folio *models.Folio) error {
+	defer r.db.lock()()
+	row := *folio
+	row.Lines = nil
+	r.db.folios.insert(&row.ID, &row)
+	folio.ID = row.ID
+	return nil
+}
+
+func (r *memoryFolioRepository) FindByReservation(reservationID uint) (*models.Folio, error) {
+	defer r.db.lock()()
+	for _, folio := range r.db.folios.all() {
+		if folio.ReservationID == reservationID {
+			return &folio, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// FindByReservationForUpdate needs no row lock because memory transactions
+// already hold the store mutex.
+func (r *memoryFolioRepository) FindByReservationForUpdate(reservationID uint) (*models.Folio, error) {
+	return r.FindByReservation(reservationID)
+}
+
+func (r *memoryFolioRepository) Save(folio *models.Folio) error {
+	defer r.db.lock()()
+	row := *folio
+	row.Lines = nil
+	r.db.folios.put(row.ID, row)
+	return nil
+}
+
+func (r *memoryFolioRepository) CreateLine(line *models.FolioLine) error {
+	defer r.db.lock()()
+	r.db.folioLines.insert(&line.ID, line)
+	return nil
+}
+
+func (r *memoryFolioRepository) FindLines(folioID uint) ([]models.FolioLine, error) {
+	defer r.db.lock()()
+	var lines []models.FolioLine
+	for _, line := range r.db.folioLines.all() {
+		if line.FolioID == folioID {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a Store backed by the given GORM connection.
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Users() UserRepository {
+	return &gormUserRepository{db: s.db}
+}
+
+func (s *gormStore) Rooms() RoomRepository {
+	return &gormRoomRepository{db: s.db}
+}
+
+func (s *gormStore) RoomTypes() RoomTypeRepository {
+	return &gormRoomTypeRepository{db: s.db}
+}
+
+func (s *gormStore) Reservations() ReservationRepository {
+	return &gormReservationRepository{db: s.db}
+}
+
+func (s *gormStore) ReservationEvents() ReservationEventRepository {
+	return &gormReservationEventRepository{db: s.db}
+}
+
+func (s *gormStore) HousekeepingTasks() HousekeepingTaskRepository {
+	return &gormHousekeepingTaskRepository{db: s.db}
+}
+
+func (s *gormStore) Rates() RateRepository {
+	return &gormRateRepository{db: s.db}
+}
+
+func (s *gormStore) Folios() FolioRepository {
+	return &gormFolioRepository{db: s.db}
+}
+
+func (s *gormStore) Payments() PaymentRepository {
+	return &gormPaymentRepository{db: s.db}
+}
+
+func (s *gormStore) Invoices() InvoiceRepository {
+	return &gormInvoiceRepository{db: s.db}
+}
+
+func (s *gormStore) Taxes() TaxRepository {
+	return &gormTaxRepository{db: s.db}
+}
+
+func (s *gormStore) ExchangeRates() ExchangeRateRepository {
+	return &gormExchangeRateRepository{db: s.db}
+}
+
+func (s *gormStore) Transaction(fn func(tx Store) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&gormStore{db: tx})
+	})
+}
+
+// gormError maps GORM errors onto the repository sentinel errors.
+func gormError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// restoreDeleted clears the DeletedAt of the soft-deleted row of model with
+// the given ID and increments its version. It returns ErrNotFound when
+// there is no such deleted row.
+func restoreDeleted(db *gorm.DB, model interface{}, id uint) error {
+	result := db.Unscoped().Model(model).Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error == nil && result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return result.Error
+}
+
+// deleteVersioned soft-deletes the row of model with the given ID provided
+// it is still at version, and increments the version so that an ETag read
+// before the delete does not match the row once restored. It returns
+// ErrVersionConflict when the row has moved on or is gone.
+func deleteVersioned(db *gorm.DB, model interface{}, id, version uint) error {
+	result := db.Model(model).Where("id = ? AND version = ?", id, version).
+		Updates(map[string]interface{}{"deleted_at": time.Now(), "version": gorm.Expr("version + 1")})
+	if result.Error == nil && result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return result.Error
+}
+
+// checkUnique looks for a row of model other than id, deleted or not, that
+// matches query on its unique columns. It returns ErrDuplicate for a live
+// row and ErrDeletedDuplicate for a deleted one, which the unique indexes
+// still count.
+func checkUnique(db *gorm.DB, model interface{}, id uint, query string, args ...interface{}) error {
+	var deleted []gorm.DeletedAt
+	if err := db.Unscoped().Model(model).Where("id <> ?", id).Where(query, args...).Pluck("deleted_at", &deleted).Error; err != nil {
+		return err
+	}
+	for _, deletedAt := range deleted {
+		if !deletedAt.Valid {
+			return ErrDuplicate
+		}
+	}
+	if len(deleted) > 0 {
+		return ErrDeletedDuplicate
+	}
+	return nil
+}
+
+// saveVersioned updates every column of value, a model whose Version field
+// is version, provided the stored row is still at that version, and bumps
+// it. It returns ErrVersionConflict when the row has moved on.
+func saveVersioned(db *gorm.DB, value interface{}, version *uint) error {
+	*version++
+	result := db.Model(value).Where("version = ?", *version-1).Select("*").Updates(value)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version--
+		return gormError(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HousekeepingTaskRepository interface {
+	Create(task *models.HousekeepingTask) error
+	FindByID(id uint) (*models.HousekeepingTask, error)
+	// FindByIDForUpdate loads a task and locks its row until the surrounding
+	// transaction ends.
+	FindByIDForUpdate(id uint) (*models.HousekeepingTask, error)
+	// FindAll returns every task, or only those in status when it is not
+	// empty, oldest first.
+	FindAll(status string) ([]models.HousekeepingTask, error)
+	Save(task *models.HousekeepingTask) error
+}
+
+type gormHousekeepingTaskRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormHousekeepingTaskRepository) Create(task *models.HousekeepingTask) error {
+	return r.db.Create(task).Error
+}
+
+func (r *gormHousekeepingTaskRepository) FindByID(id uint) (*models.HousekeepingTask, error) {
+	var task models.HousekeepingTask
+	if err := r.db.First(&task, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &task, nil
+}
+
+func (r *gormHousekeepingTaskRepository) FindByIDForUpdate(id uint) (*models.HousekeepingTask, error) {
+	var task models.HousekeepingTask
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&task, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &task, nil
+}
+
+func (r *gormHousekeepingTaskRepository) FindAll(status string) ([]models.HousekeepingTask, error) {
+	query := r.db.Order("id")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var tasks []models.HousekeepingTask
+	if err := query.Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *gormHousekeepingTaskRepository) Save(task *models.HousekeepingTask) error {
+	return r.db.Save(task).Error
+}
+
+type memoryHousekeepingTaskRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryHousekeepingTaskRepository) Create(task *models.HousekeepingTask) error {
+	defer r.db.lock()()
+	r.db.housekeepingTasks.insert(&task.ID, task)
+	return nil
+}
+
+func (r *memoryHousekeepingTaskRepository) FindByID(id uint) (*models.HousekeepingTask, error) {
+	defer r.db.lock()()
+	task, ok := r.db.housekeepingTasks.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &task, nil
+}
+
+// FindByIDForUpdate needs no row lock because memory transactions already
+// hold the store mutex.
+func (r *memoryHousekeepingTaskRepository) FindByIDForUpdate(id uint) (*models.HousekeepingTask, error) {
+	return r.FindByID(id)
+}
+
+func (r *memoryHousekeepingTaskRepository) FindAll(status string) ([]models.HousekeepingTask, error) {
+	defer r.db.lock()()
+	var tasks []models.HousekeepingTask
+	for _, task := range r.db.housekeepingTasks.all() {
+		if status == "" || task.Status == status {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (r *memoryHousekeepingTaskRepository) Save(task *models.HousekeepingTask) error {
+	defer r.db.lock()()
+	if task.ID == 0 {
+		r.db.housekeepingTasks.insert(&task.ID, task)
+		return nil
+	}
+	r.db.housekeepingTasks.put(task.ID, *task)
+	return nil
+}
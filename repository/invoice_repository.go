@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InvoiceRepository stores issued invoices. There is no way to change or
+// delete an invoice once created.
+type InvoiceRepository interface {
+	// NextNumber reserves the next invoice sequence number of year. The
+	// sequence row stays locked until the surrounding transaction ends, so
+	// concurrent callers get consecutive numbers without gaps or repeats.
+	NextNumber(year int) (int, error)
+	// Create inserts an invoice with its lines.
+	Create(invoice *models.Invoice) error
+	FindByID(id uint) (*models.Invoice, error)
+	FindByReservation(reservationID uint) (*models.Invoice, error)
+}
+
+type gormInvoiceRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormInvoiceRepository) NextNumber(year int) (int, error) {
+	// Two transactions may both be first of the year; only one insert wins
+	// and the other waits on the row lock below.
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.InvoiceSequence{Year: year}).Error; err != nil {
+		return 0, err
+	}
+
+	var sequence models.InvoiceSequence
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sequence, "year = ?", year).Error; err != nil {
+		return 0, err
+	}
+	sequence.LastNumber++
+	if err := r.db.Model(&sequence).Where("year = ?", year).Update("last_number", sequence.LastNumber).Error; err != nil {
+		return 0, err
+	}
+	return sequence.LastNumber, nil
+}
+
+func (r *gormInvoiceRepository) Create(invoice *models.Invoice) error {
+	return r.db.Create(invoice).Error
+}
+
+func (r *gormInvoiceRepository) FindByID(id uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := r.db.Preload("Lines", orderByID).First(&invoice, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &invoice, nil
+}
+
+func (r *gormInvoiceRepository) FindByReservation(reservationID uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := r.db.Preload("Lines", orderByID).Where("reservation_id = ?", reservationID).First(&invoice).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &invoice, nil
+}
+
+func orderByID(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+type memoryInvoiceRepository struct {
+	db *memoryDB
+}
+
+// NextNumber needs no row lock because memory transactions already hold the
+// store mutex.
+func (r *memoryInvoiceRepository) NextNumber(year int) (int, error) {
+	defer r.db.lock()()
+	r.db.invoiceSequences[year]++
+	return r.db.invoiceSequences[year], nil
+}
+
+func (r *memoryInvoiceRepository) Create(invoice *models.Invoice) error {
+	defer r.db.lock()()
+	for _, existing := range r.db.invoices.all() {
+		if existing.Number == invoice.Number || existing.ReservationID == invoice.ReservationID {
+			return ErrDuplicate
+		}
+	}
+
+	row := *invoice
+	row.Lines = nil
+	r.db.invoices.insert(&row.ID, &row)
+	invoice.ID = row.ID
+	for i := range invoice.Lines {
+		invoice.Lines[i].InvoiceID = invoice.ID
+		r.db.invoiceLines.insert(&invoice.Lines[i].ID, &invoice.Lines[i])
+	}
+	return nil
+}
+
+func (r *memoryInvoiceRepository) FindByID(id uint) (*models.Invoice, error) {
+	defer r.db.lock()()
+	invoice, ok := r.db.invoices.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return r.withLines(invoice), nil
+}
+
+func (r *memoryInvoiceRepository) FindByReservation(reservationID uint) (*models.Invoice, error) {
+	defer r.db.lock()()
+	for _, invoice := range r.db.invoices.all() {
+		if invoice.ReservationID == reservationID {
+			return r.withLines(invoice), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryInvoiceRepository) withLines(invoice models.Invoice) *models.Invoice {
+	for _, line := range r.db.invoiceLines.all() {
+		if line.InvoiceID == invoice.ID {
+			invoice.Lines = append(invoice.Lines, line)
+		}
+	}
+	return &invoice
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryTable is an auto-incrementing in-memory table keyed by primary key.
+type memoryTable[T any] struct {
+	rows   map[uint]T
+	nextID uint
+}
+
+func newMemoryTable[T any]() *memoryTable[T] {
+	return &memoryTable[T]{rows: make(map[uint]T)}
+}
+
+func (t *memoryTable[T]) insert(id *uint, row *T) {
+	t.nextID++
+	*id = t.nextID
+	t.rows[*id] = *row
+}
+
+func (t *memoryTable[T]) get(id uint) (T, bool) {
+	row, ok := t.rows[id]
+	return row, ok
+}
+
+func (t *memoryTable[T]) put(id uint, row T) {
+	if id > t.nextID {
+		t.nextID = id
+	}
+	t.rows[id] = row
+}
+
+func (t *memoryTable[T]) clone() *memoryTable[T] {
+	rows := make(map[uint]T, len(t.rows))
+	for id, row := range t.rows {
+		rows[id] = row
+	}
+	return &memoryTable[T]{rows: rows, nextID: t.nextID}
+}
+
+func (t *memoryTable[T]) delete(id uint) {
+	delete(t.rows, id)
+}
+
+// all returns every row ordered by primary key.
+func (t *memoryTable[T]) all() []T {
+	ids := make([]uint, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	rows := make([]T, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, t.rows[id])
+	}
+	return rows
+}
+
+// moveRow moves the row with the given ID from one table to another,
+// passing it through change on the way, and reports whether it was there.
+func moveRow[T any](from, to *memoryTable[T], id uint, change func(*T)) bool {
+	row, ok := from.get(id)
+	if !ok {
+		return false
+	}
+	change(&row)
+	to.put(id, row)
+	from.delete(id)
+	return true
+}
+
+// deletedNow is the DeletedAt GORM sets on a soft delete.
+func deletedNow() gorm.DeletedAt {
+	return gorm.DeletedAt{Time: time.Now(), Valid: true}
+}
+
+type memoryTables struct {
+	users        *memoryTable[models.User]
+	rooms        *memoryTable[models.Room]
+	reservations *memoryTable[models.Reservation]
+
+	// Deleted users, rooms and reservations are moved out of the tables
+	// above, so that only the queries that ask for them see them.
+	deletedUsers        *memoryTable[models.User]
+	deletedRooms        *memoryTable[models.Room]
+	deletedReservations *memoryTable[models.Reservation]
+
+	reservationNights *memoryTable[models.ReservationNight]
+	reservationEvents *memoryTable[models.ReservationEvent]
+	housekeepingTasks *memoryTable[models.HousekeepingTask]
+	ratePlans         *memoryTable[models.RatePlan]
+	rateOverrides     *memoryTable[models.RateOverride]
+	folios            *memoryTable[models.Folio]
+	folioLines        *memoryTable[models.FolioLine]
+	payments          *memoryTable[models.Payment]
+	invoices          *memoryTable[models.Invoice]
+	invoiceLines      *memoryTable[models.InvoiceLine]
+	invoiceSequences  map[int]int
+	taxes             *memoryTable[models.Tax]
+	reservationTaxes  *memoryTable[models.ReservationTax]
+	exchangeRates     *memoryTable[models.ExchangeRate]
+	roomTypes         *memoryTable[models.RoomType]
+	segments          *memoryTable[models.ReservationSegment]
+}
+
+func (t *memoryTables) clone() *memoryTables {
+	sequences := make(map[int]int, len(t.invoiceSequences))
+	for year, last := range t.invoiceSequences {
+		sequences[year] = last
+	}
+	return &memoryTables{
+		users:        t.users.clone(),
+		rooms:        t.rooms.clone(),
+		reservations: t.reservations.clone(),
+
+		deletedUsers:        t.deletedUsers.clone(),
+		deletedRooms:        t.deletedRooms.clone(),
+		deletedReservations: t.deletedReservations.clone(),
+
+		reservationNights: t.reservationNights.clone(),
+		reservationEvents: t.reservationEvents.clone(),
+		housekeepingTasks: t.housekeepingTasks.clone(),
+		ratePlans:         t.ratePlans.clone(),
+		rateOverrides:     t.rateOverrides.clone(),
+		folios:            t.folios.clone(),
+		folioLines:        t.folioLines.clone(),
+		payments:          t.payments.clone(),
+		invoices:          t.invoices.clone(),
+		invoiceLines:      t.invoiceLines.clone(),
+		invoiceSequences:  sequences,
+		taxes:             t.taxes.clone(),
+		reservationTaxes:  t.reservationTaxes.clone(),
+		exchangeRates:     t.exchangeRates.clone(),
+		roomTypes:         t.roomTypes.clone(),
+		segments:          t.segments.clone(),
+	}
+}
+
+// memoryDB is a view of the tables guarded by mu. Views handed out inside a
+// transaction use a no-op lock because the transaction already holds the
+// store mutex.
+type memoryDB struct {
+	*memoryTables
+	mu sync.Locker
+}
+
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
+
+type memoryStore struct {
+	db *memoryDB
+}
+
+// NewMemoryStore returns a Store that keeps everything in process memory. It
+// starts with the same reference data the migrations seed and is intended
+// for tests and local experiments.
+func NewMemoryStore() Store {
+	s := &memoryStore{db: &memoryDB{
+		memoryTables: &memoryTables{
+			users:        newMemoryTable[models.User](),
+			rooms:        newMemoryTable[models.Room](),
+			reservations: newMemoryTable[models.Reservation](),
+
+			deletedUsers:        newMemoryTable[models.User](),
+			deletedRooms:        newMemoryTable[models.Room](),
+			deletedReservations: newMemoryTable[models.Reservation](),
+
+			reservationNights: newMemoryTable[models.ReservationNight](),
+			reservationEvents: newMemoryTable[models.ReservationEvent](),
+			housekeepingTasks: newMemoryTable[models.HousekeepingTask](),
+			ratePlans:         newMemoryTable[models.RatePlan](),
+			rateOverrides:     newMemoryTable[models.RateOverride](),
+			folios:            newMemoryTable[models.Folio](),
+			folioLines:        newMemoryTable[models.FolioLine](),
+			payments:          newMemoryTable[models.Payment](),
+			invoices:          newMemoryTable[models.Invoice](),
+			invoiceLines:      newMemoryTable[models.InvoiceLine](),
+			invoiceSequences:  make(map[int]int),
+			taxes:             newMemoryTable[models.Tax](),
+			reservationTaxes:  newMemoryTable[models.ReservationTax](),
+			exchangeRates:     newMemoryTable[models.ExchangeRate](),
+			roomTypes:         newMemoryTable[models.RoomType](),
+			segments:          newMemoryTable[models.ReservationSegment](),
+		},
+		mu: &sync.Mutex{},
+	}}
+
+	// Rate plans seeded by migration 0005, with the terms of migration 0008.
+	for _, plan := range []models.RatePlan{
+		{Code: "standard", Name: "Standard rate", CancellationPolicy: "flexible"},
+		{Code: "non-refundable", Name: "Non-refundable", PercentAdjustment: -10, DepositPercent: 100, CancellationPolicy: "non-refundable"},
+		{Code: "breakfast-included", Name: "Breakfast included", NightlySupplement: 1500, CancellationPolicy: "flexible"},
+	} {
+		s.Rates().CreatePlan(&plan)
+	}
+	return s
+}
+
+func (s *memoryStore) Users() UserRepository {
+	return &memoryUserRepository{db: s.db}
+}
+
+func (s *memoryStore) Rooms() RoomRepository {
+	return &memoryRoomRepository{db: s.db}
+}
+
+func (s *memoryStore) RoomTypes() RoomTypeRepository {
+	return &memoryRoomTypeRepository{db: s.db}
+}
+
+func (s *memoryStore) Reservations() ReservationRepository {
+	return &memoryReservationRepository{db: s.db}
+}
+
+func (s *memoryStore) ReservationEvents() ReservationEventRepository {
+	return &memoryReservationEventRepository{db: s.db}
+}
+
+func (s *memoryStore) HousekeepingTasks() HousekeepingTaskRepository {
+	return &memoryHousekeepingTaskRepository{db: s.db}
+}
+
+func (s *memoryStore) Rates() RateRepository {
+	return &memoryRateRepository{db: s.db}
+}
+
+func (s *memoryStore) Folios() FolioRepository {
+	return &memoryFolioRepository{db: s.db}
+}
+
+func (s *memoryStore) Payments() PaymentRepository {
+	return &memoryPaymentRepository{db: s.db}
+}
+
+func (s *memoryStore) Invoices() InvoiceRepository {
+	return &memoryInvoiceRepository{db: s.db}
+}
+
+func (s *memoryStore) Taxes() TaxRepository {
+	return &memoryTaxRepository{db: s.db}
+}
+
+func (s *memoryStore) ExchangeRates() ExchangeRateRepository {
+	return &memoryExchangeRateRepository{db: s.db}
+}
+
+// Transaction serializes fn against every other access to the store and
+// restores a snapshot of the tables if fn fails.
+func (s *memoryStore) Transaction(fn func(tx Store) error) error {
+	defer s.db.lock()()
+	snapshot := s.db.memoryTables.clone()
+	tx := &memoryStore{db: &memoryDB{memoryTables: s.db.memoryTables, mu: noopLocker{}}}
+	if err := fn(tx); err != nil {
+		*s.db.memoryTables = *snapshot
+		return err
+	}
+	return nil
+}
+
+// lock acquires the store mutex and returns the matching unlock function.
+func (db *memoryDB) lock() func() {
+	db.mu.Lock()
+	return db.mu.Unlock
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"testing"
+)
+
+// TestVersionedSave saves a user, a room and a reservation twice from the
+// same read: the first save moves the record to version 2, the second is
+// refused because it was made against version 1.
+func TestVersionedSave(t *testing.T) {
+	store := NewMemoryStore()
+	user := &models.User{Username: "guest", Email: "guest@example.com", Role: "customer"}
+	room := &models.Room{Number: "101", Status: "available"}
+	reservation := &models.Reservation{UserID: 1, RoomID: 1, Status: "pending"}
+	if err := store.Users().Create(user); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Rooms().Create(room); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Reservations().Create(reservation); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		save    func() error
+		version func() uint
+	}{
+		{name: "user", save: func() error { return store.Users().Save(user) }, version: func() uint { return user.Version }},
+		{name: "room", save: func() error { return store.Rooms().Save(room) }, version: func() uint { return room.Version }},
+		{name: "reservation", save: func() error { return store.Reservations().Save(reservation) }, version: func() uint { return reservation.Version }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.version() != 1 {
+				t.Fatalf("created at version %d, want 1", tt.version())
+			}
+			if err := tt.save(); err != nil || tt.version() != 2 {
+				t.Fatalf("first save: got %v at version %d, want version 2", err, tt.version())
+			}
+			user.Version, room.Version, reservation.Version = 1, 1, 1
+			if err := tt.save(); !errors.Is(err, ErrVersionConflict) {
+				t.Errorf("stale save: got %v, want %v", err, ErrVersionConflict)
+			}
+		})
+	}
+}
+
+func TestTransactionRollsBackOnConflict(t *testing.T) {
+	store := NewMemoryStore()
+	room := &models.Room{Number: "101", Status: "available"}
+	if err := store.Rooms().Create(room); err != nil {
+		t.Fatal(err)
+	}
+
+	err := store.Transaction(func(tx Store) error {
+		changed := *room
+		changed.Status = "cleaning"
+		if err := tx.Rooms().Save(&changed); err != nil {
+			return err
+		}
+		return tx.Rooms().Save(room)
+	})
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("got %v, want %v", err, ErrVersionConflict)
+	}
+	stored, err := store.Rooms().FindByID(room.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != "available" || stored.Version != 1 {
+		t.Errorf("got %s at version %d, want the room untouched", stored.Status, stored.Version)
+	}
+}
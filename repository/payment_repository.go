@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+
+	"gorm.io/gorm"
+)
+
+type PaymentRepository interface {
+	Create(payment *models.Payment) error
+	// FindByReservation returns the payments and refunds of a reservation,
+	// oldest first.
+	FindByReservation(reservationID uint) ([]models.Payment, error)
+}
+
+type gormPaymentRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormPaymentRepository) Create(payment *models.Payment) error {
+	return r.db.Create(payment).Error
+}
+
+func (r *gormPaymentRepository) FindByReservation(reservationID uint) ([]models.Payment, error) {
+	var payments []models.Payment
+	if err := r.db.Where("reservation_id = ?", reservationID).Order("id").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+type memoryPaymentRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryPaymentRepository) Create(payment *models.Payment) error {
+	defer r.db.lock()()
+	r.db.payments.insert(&payment.ID, payment)
+	return nil
+}
+
+func (r *memoryPaymentRepository) FindByReservation(reservationID uint) ([]models.Payment, error) {
+	defer r.db.lock()()
+	var payments []models.Payment
+	for _, payment := range r.db.payments.all() {
+		if payment.ReservationID == reservationID {
+			payments = append(payments, payment)
+		}
+	}
+	return payments, nil
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+
+	"gorm.io/gorm"
+)
+
+// RateRepository stores rate plans and the nightly rate overrides per room
+// type.
+type RateRepository interface {
+	CreatePlan(plan *models.RatePlan) error
+	FindPlanByID(id uint) (*models.RatePlan, error)
+	FindPlanByCode(code string) (*models.RatePlan, error)
+	FindAllPlans() ([]models.RatePlan, error)
+	SavePlan(plan *models.RatePlan) error
+
+	CreateOverride(override *models.RateOverride) error
+	FindAllOverrides() ([]models.RateOverride, error)
+	FindOverridesByRoomType(roomTypeID uint) ([]models.RateOverride, error)
+	DeleteOverride(id uint) error
+}
+
+type gormRateRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRateRepository) CreatePlan(plan *models.RatePlan) error {
+	return r.db.Create(plan).Error
+}
+
+func (r *gormRateRepository) FindPlanByID(id uint) (*models.RatePlan, error) {
+	var plan models.RatePlan
+	if err := r.db.First(&plan, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &plan, nil
+}
+
+func (r *gormRateRepository) FindPlanByCode(code string) (*models.RatePlan, error) {
+	var plan models.RatePlan
+	if err := r.db.Where("code = ?", code).First(&plan).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &plan, nil
+}
+
+func (r *gormRateRepository) FindAllPlans() ([]models.RatePlan, error) {
+	var plans []models.RatePlan
+	if err := r.db.Order("id").Find(&plans).Error; err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func (r *gormRateRepository) SavePlan(plan *models.RatePlan) error {
+	return r.db.Save(plan).Error
+}
+
+func (r *gormRateRepository) CreateOverride(override *models.RateOverride) error {
+	return r.db.Create(override).Error
+}
+
+func (r *gormRateRepository) FindAllOverrides() ([]models.RateOverride, error) {
+	var overrides []models.RateOverride
+	if err := r.db.Order("id").Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+func (r *gormRateRepository) FindOverridesByRoomType(roomTypeID uint) ([]models.RateOverride, error) {
+	var overrides []models.RateOverride
+	if err := r.db.Where("room_type_id = ?", roomTypeID).Order("id").Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+func (r *gormRateRepository) DeleteOverride(id uint) error {
+	return r.db.Delete(&models.RateOverride{}, id).Error
+}
+
+type memoryRateRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryRateRepository) CreatePlan(plan *models.RatePlan) error {
+	defer r.db.lock()()
+	if err := r.checkUniquePlan(plan); err != nil {
+		return err
+	}
+	r.db.ratePlans.insert(&plan.ID, plan)
+	return nil
+}
+
+func (r *memoryRateRepository) FindPlanByID(id uint) (*models.RatePlan, error) {
+	defer r.db.lock()()
+	plan, ok := r.db.ratePlans.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &plan, nil
+}
+
+func (r *memoryRateRepository) FindPlanByCode(code string) (*models.RatePlan, error) {
+	defer r.db.lock()()
+	for _, plan := range r.db.ratePlans.all() {
+		if plan.Code == code {
+			return &plan, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryRateRepository) FindAllPlans() ([]models.RatePlan, error) {
+	defer r.db.lock()()
+	return r.db.ratePlans.all(), nil
+}
+
+func (r *memoryRateRepository) SavePlan(plan *models.RatePlan) error {
+	defer r.db.lock()()
+	if err := r.checkUniquePlan(plan); err != nil {
+		return err
+	}
+	if plan.ID == 0 {
+		r.db.ratePlans.insert(&plan.ID, plan)
+		return nil
+	}
+	r.db.ratePlans.put(plan.ID, *plan)
+	return nil
+}
+
+// checkUniquePlan mirrors the unique index on the plan code.
+func (r *memoryRateRepository) checkUniquePlan(plan *models.RatePlan) error {
+	for _, other := range r.db.ratePlans.all() {
+		if other.ID != plan.ID && other.Code == plan.Code {
+			return ErrDuplicate
+		}
+	}
+	return nil
+}
+
+func (r *memoryRateRepository) CreateOverride(override *models.RateOverride) error {
+	defer r.db.lock()()
+	r.db.rateOverrides.insert(&override.ID, override)
+	return nil
+}
+
+func (r *memoryRateRepository) FindAllOverrides() ([]models.RateOverride, error) {
+	defer r.db.lock()()
+	return r.db.rateOverrides.all(), nil
+}
+
+func (r *memoryRateRepository) FindOverridesByRoomType(roomTypeID uint) ([]models.RateOverride, error) {
+	defer r.db.lock()()
+	var overrides []models.RateOverride
+	for _, override := range r.db.rateOverrides.all() {
+		if override.RoomTypeID == roomTypeID {
+			overrides = append(overrides, override)
+		}
+	}
+	return overrides, nil
+}
+
+func (r *memoryRateRepository) DeleteOverride(id uint) error {
+	defer r.db.lock()()
+	r.db.rateOverrides.delete(id)
+	return nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+
+	"gorm.io/gorm"
+)
+
+type ReservationEventRepository interface {
+	Create(event *models.ReservationEvent) error
+	// FindByReservation returns the history of a reservation, oldest first.
+	FindByReservation(reservationID uint) ([]models.ReservationEvent, error)
+}
+
+type gormReservationEventRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormReservationEventRepository) Create(event *models.ReservationEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *gormReservationEventRepository) FindByReservation(reservationID uint) ([]models.ReservationEvent, error) {
+	var events []models.ReservationEvent
+	if err := r.db.Where("reservation_id = ?", reservationID).Order("id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+type memoryReservationEventRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryReservationEventRepository) Create(event *models.ReservationEvent) error {
+	defer r.db.lock()()
+	r.db.reservationEvents.insert(&event.ID, event)
+	return nil
+}
+
+func (r *memoryReservationEventRepository) FindByReservation(reservationID uint) ([]models.ReservationEvent, error) {
+	defer r.db.lock()()
+	var events []models.ReservationEvent
+	for _, event := range r.db.reservationEvents.all() {
+		if event.ReservationID == reservationID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
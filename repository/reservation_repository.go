@@ -0,0 +1,606 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevenueRow is an amount charged for the night of Date, of which Tax is
+// tax, in minor units of Currency. BookedAt is when the reservation it
+// belongs to was made.
+type RevenueRow struct {
+	Date     time.Time
+	Amount   int64
+	Tax      int64
+	Currency string
+	BookedAt time.Time
+}
+
+// ReservationRepository stores reservations. Create also inserts the nightly
+// breakdown in Nights, the taxes in Taxes and the room segments in Segments;
+// FindByID and FindByIDForUpdate load them back and the overlap queries load
+// the segments. Save never touches any of them: pricing only changes through
+// ReplacePricing and segments through ReplaceSegments.
+type ReservationRepository interface {
+	Create(reservation *models.Reservation) error
+	FindByID(id uint) (*models.Reservation, error)
+	// FindByIDForUpdate loads a reservation and locks its row until the
+	// surrounding transaction ends.
+	FindByIDForUpdate(id uint) (*models.Reservation, error)
+	FindAll() ([]models.Reservation, error)
+	// FindByUser returns the reservations booked for a user whose status is
+	// one of statuses.
+	FindByUser(userID uint, statuses []string) ([]models.Reservation, error)
+	// FindOverlapping returns the reservations of any room that overlap
+	// the half-open range [start, end) and whose status is one of statuses.
+	FindOverlapping(start, end time.Time, statuses []string) ([]models.Reservation, error)
+	// FindOverlappingRoom is FindOverlapping restricted to the reservations
+	// in a single room, or with a segment in it. Whether that segment
+	// overlaps the range is left to the caller.
+	FindOverlappingRoom(roomID uint, start, end time.Time, statuses []string) ([]models.Reservation, error)
+	Save(reservation *models.Reservation) error
+	// Delete marks a reservation at version deleted, hiding it from every
+	// other query but RevenueRows, and increments its Version. Its pricing,
+	// segments and history are kept. It returns ErrVersionConflict when the
+	// reservation changed or is gone.
+	Delete(id, version uint) error
+	// Restore undoes Delete and increments the Version of the reservation.
+	Restore(id uint) error
+	// FindDeletedBefore returns the reservations deleted before the given
+	// time.
+	FindDeletedBefore(before time.Time) ([]models.Reservation, error)
+	// Purge removes a deleted reservation for good, with its pricing,
+	// segments and history.
+	Purge(id uint) error
+	// ExistsInRoom and ExistsForUser report whether any reservation,
+	// deleted or not, was ever in the room or booked for the user.
+	ExistsInRoom(roomID uint) (bool, error)
+	ExistsForUser(userID uint) (bool, error)
+	// ReplacePricing swaps the nightly breakdown and taxes of a reservation.
+	ReplacePricing(reservationID uint, nights []models.ReservationNight, taxes []models.ReservationTax) error
+	// ReplaceSegments swaps the room segments of a reservation.
+	ReplaceSegments(reservationID uint, segments []models.ReservationSegment) error
+
+	// RevenueRows returns the stored nights and taxes of the reservations
+	// fully contained in [start, end] whose status is one of statuses.
+	// Deleted reservations stay in the books until they are purged.
+	RevenueRows(start, end time.Time, statuses []string) ([]RevenueRow, error)
+}
+
+type gormReservationRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormReservationRepository) Create(reservation *models.Reservation) error {
+	reservation.Version = 1
+	return r.db.Create(reservation).Error
+}
+
+func (r *gormReservationRepository) FindByID(id uint) (*models.Reservation, error) {
+	var reservation models.Reservation
+	if err := r.db.Preload("Nights", orderByDate).Preload("Taxes", orderByDate).Preload("Segments", orderByStart).First(&reservation, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &reservation, nil
+}
+
+func (r *gormReservationRepository) FindByIDForUpdate(id uint) (*models.Reservation, error) {
+	var reservation models.Reservation
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Nights", orderByDate).Preload("Taxes", orderByDate).Preload("Segments", orderByStart).First(&reservation, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &reservation, nil
+}
+
+func (r *gormReservationRepository) FindAll() ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *gormReservationRepository) FindByUser(userID uint, statuses []string) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.Where("user_id = ? AND status IN ?", userID, statuses).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *gormReservationRepository) FindOverlapping(start, end time.Time, statuses []string) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.Preload("Segments", orderByStart).Where("start_date < ? AND end_date > ? AND status IN ?", end, start, statuses).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *gormReservationRepository) FindOverlappingRoom(roomID uint, start, end time.Time, statuses []string) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.Preload("Segments", orderByStart).
+		Where("(room_id = ? OR id IN (?)) AND start_date < ? AND end_date > ? AND status IN ?",
+			roomID, r.db.Model(&models.ReservationSegment{}).Select("reservation_id").Where("room_id = ?", roomID), end, start, statuses).
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *gormReservationRepository) Save(reservation *models.Reservation) error {
+	if reservation.ID == 0 {
+		reservation.Version = 1
+		return r.db.Omit(clause.Associations).Create(reservation).Error
+	}
+	return saveVersioned(r.db.Omit(clause.Associations), reservation, &reservation.Version)
+}
+
+func (r *gormReservationRepository) Delete(id, version uint) error {
+	return deleteVersioned(r.db, &models.Reservation{}, id, version)
+}
+
+func (r *gormReservationRepository) Restore(id uint) error {
+	return restoreDeleted(r.db, &models.Reservation{}, id)
+}
+
+func (r *gormReservationRepository) FindDeletedBefore(before time.Time) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.Unscoped().Where("deleted_at < ?", before).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *gormReservationRepository) Purge(id uint) error {
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.Reservation{}, id)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+	if err := r.deletePricing(id); err != nil {
+		return err
+	}
+	if err := r.db.Where("reservation_id = ?", id).Delete(&models.ReservationSegment{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("reservation_id = ?", id).Delete(&models.ReservationEvent{}).Error
+}
+
+func (r *gormReservationRepository) ExistsInRoom(roomID uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Reservation{}).
+		Where("room_id = ? OR id IN (?)", roomID, r.db.Model(&models.ReservationSegment{}).Select("reservation_id").Where("room_id = ?", roomID)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormReservationRepository) ExistsForUser(userID uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Reservation{}).Where("user_id = ?", userID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormReservationRepository) deletePricing(reservationID uint) error {
+	if err := r.db.Where("reservation_id = ?", reservationID).Delete(&models.ReservationNight{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("reservation_id = ?", reservationID).Delete(&models.ReservationTax{}).Error
+}
+
+func (r *gormReservationRepository) ReplacePricing(reservationID uint, nights []models.ReservationNight, taxes []models.ReservationTax) error {
+	if err := r.deletePricing(reservationID); err != nil {
+		return err
+	}
+	if len(nights) > 0 {
+		for i := range nights {
+			nights[i].ID = 0
+			nights[i].ReservationID = reservationID
+		}
+		if err := r.db.Create(&nights).Error; err != nil {
+			return err
+		}
+	}
+	if len(taxes) > 0 {
+		for i := range taxes {
+			taxes[i].ID = 0
+			taxes[i].ReservationID = reservationID
+		}
+		if err := r.db.Create(&taxes).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *gormReservationRepository) ReplaceSegments(reservationID uint, segments []models.ReservationSegment) error {
+	if err := r.db.Where("reservation_id = ?", reservationID).Delete(&models.ReservationSegment{}).Error; err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+	for i := range segments {
+		segments[i].ID = 0
+		segments[i].ReservationID = reservationID
+	}
+	return r.db.Create(&segments).Error
+}
+
+func orderByDate(db *gorm.DB) *gorm.DB {
+	return db.Order("date, id")
+}
+
+func orderByStart(db *gorm.DB) *gorm.DB {
+	return db.Order("start_date, id")
+}
+
+const revenueFilter = "reservations.start_date >= ? AND reservations.end_date <= ? AND reservations.status IN ?"
+
+// revenueColumns are the reservation columns every revenue row carries.
+const revenueColumns = "reservations.currency as currency, reservations.created_at as booked_at"
+
+// RevenueRows leaves grouping to the caller so that the reports do not
+// depend on dialect-specific date functions.
+func (r *gormReservationRepository) RevenueRows(start, end time.Time, statuses []string) ([]RevenueRow, error) {
+	var nights []RevenueRow
+	if err := r.db.Model(&models.ReservationNight{}).
+		Select("reservation_nights.date as date, reservation_nights.amount as amount, "+revenueColumns).
+		Joins("join reservations on reservation_nights.reservation_id = reservations.id").
+		Where(revenueFilter, start, end, statuses).
+		Scan(&nights).Error; err != nil {
+		return nil, err
+	}
+
+	var taxes []revenueTax
+	if err := r.db.Model(&models.ReservationTax{}).
+		Select("reservation_taxes.date as date, reservation_taxes.amount as amount, reservation_taxes.inclusive as inclusive, "+revenueColumns).
+		Joins("join reservations on reservation_taxes.reservation_id = reservations.id").
+		Where(revenueFilter, start, end, statuses).
+		Scan(&taxes).Error; err != nil {
+		return nil, err
+	}
+	return append(nights, taxRows(taxes)...), nil
+}
+
+type memoryReservationRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryReservationRepository) Create(reservation *models.Reservation) error {
+	defer r.db.lock()()
+	stampCreated(reservation)
+	row := *reservation
+	row.Nights = nil
+	row.Taxes = nil
+	row.Segments = nil
+	row.Version = 1
+	r.db.reservations.insert(&row.ID, &row)
+	reservation.ID = row.ID
+	reservation.Version = 1
+	r.insertNights(reservation.ID, reservation.Nights)
+	r.insertTaxes(reservation.ID, reservation.Taxes)
+	r.insertSegments(reservation.ID, reservation.Segments)
+	return nil
+}
+
+// stampCreated sets the creation time of a new reservation the way GORM
+// does, since revenue is converted at the rates of the booking day.
+func stampCreated(reservation *models.Reservation) {
+	if reservation.CreatedAt.IsZero() {
+		reservation.CreatedAt = time.Now()
+	}
+}
+
+// insertNights stores nights for a reservation, assigning their IDs in place.
+func (r *memoryReservationRepository) insertNights(reservationID uint, nights []models.ReservationNight) {
+	for i := range nights {
+		nights[i].ReservationID = reservationID
+		r.db.reservationNights.insert(&nights[i].ID, &nights[i])
+	}
+}
+
+// insertTaxes stores taxes for a reservation, assigning their IDs in place.
+func (r *memoryReservationRepository) insertTaxes(reservationID uint, taxes []models.ReservationTax) {
+	for i := range taxes {
+		taxes[i].ReservationID = reservationID
+		r.db.reservationTaxes.insert(&taxes[i].ID, &taxes[i])
+	}
+}
+
+// insertSegments stores segments for a reservation, assigning their IDs in
+// place.
+func (r *memoryReservationRepository) insertSegments(reservationID uint, segments []models.ReservationSegment) {
+	for i := range segments {
+		segments[i].ReservationID = reservationID
+		r.db.segments.insert(&segments[i].ID, &segments[i])
+	}
+}
+
+// segmentsOf returns the segments of a reservation ordered by start.
+func (r *memoryReservationRepository) segmentsOf(reservationID uint) []models.ReservationSegment {
+	var segments []models.ReservationSegment
+	for _, segment := range r.db.segments.all() {
+		if segment.ReservationID == reservationID {
+			segments = append(segments, segment)
+		}
+	}
+	sort.SliceStable(segments, func(i, j int) bool { return segments[i].StartDate.Before(segments[j].StartDate) })
+	return segments
+}
+
+func (r *memoryReservationRepository) FindByID(id uint) (*models.Reservation, error) {
+	defer r.db.lock()()
+	reservation, ok := r.db.reservations.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	for _, night := range r.db.reservationNights.all() {
+		if night.ReservationID == id {
+			reservation.Nights = append(reservation.Nights, night)
+		}
+	}
+	sort.Slice(reservation.Nights, func(i, j int) bool { return reservation.Nights[i].Date.Before(reservation.Nights[j].Date) })
+	for _, tax := range r.db.reservationTaxes.all() {
+		if tax.ReservationID == id {
+			reservation.Taxes = append(reservation.Taxes, tax)
+		}
+	}
+	sort.SliceStable(reservation.Taxes, func(i, j int) bool { return reservation.Taxes[i].Date.Before(reservation.Taxes[j].Date) })
+	reservation.Segments = r.segmentsOf(id)
+	return &reservation, nil
+}
+
+// FindByIDForUpdate needs no row lock because memory transactions already
+// hold the store mutex.
+func (r *memoryReservationRepository) FindByIDForUpdate(id uint) (*models.Reservation, error) {
+	return r.FindByID(id)
+}
+
+func (r *memoryReservationRepository) FindAll() ([]models.Reservation, error) {
+	defer r.db.lock()()
+	return r.db.reservations.all(), nil
+}
+
+func (r *memoryReservationRepository) FindByUser(userID uint, statuses []string) ([]models.Reservation, error) {
+	defer r.db.lock()()
+	var reservations []models.Reservation
+	for _, reservation := range r.db.reservations.all() {
+		if reservation.UserID == userID && contains(statuses, reservation.Status) {
+			reservations = append(reservations, reservation)
+		}
+	}
+	return reservations, nil
+}
+
+func (r *memoryReservationRepository) FindOverlapping(start, end time.Time, statuses []string) ([]models.Reservation, error) {
+	defer r.db.lock()()
+	var reservations []models.Reservation
+	for _, reservation := range r.db.reservations.all() {
+		if reservation.StartDate.Before(end) && reservation.EndDate.After(start) && contains(statuses, reservation.Status) {
+			reservation.Segments = r.segmentsOf(reservation.ID)
+			reservations = append(reservations, reservation)
+		}
+	}
+	return reservations, nil
+}
+
+func (r *memoryReservationRepository) FindOverlappingRoom(roomID uint, start, end time.Time, statuses []string) ([]models.Reservation, error) {
+	reservations, err := r.FindOverlapping(start, end, statuses)
+	if err != nil {
+		return nil, err
+	}
+	var matching []models.Reservation
+	for _, reservation := range reservations {
+		inRoom := reservation.RoomID == roomID
+		for _, segment := range reservation.Segments {
+			inRoom = inRoom || segment.RoomID == roomID
+		}
+		if inRoom {
+			matching = append(matching, reservation)
+		}
+	}
+	return matching, nil
+}
+
+func (r *memoryReservationRepository) Save(reservation *models.Reservation) error {
+	defer r.db.lock()()
+	if reservation.ID == 0 {
+		stampCreated(reservation)
+	}
+	row := *reservation
+	row.Nights = nil
+	row.Taxes = nil
+	row.Segments = nil
+	if row.ID == 0 {
+		row.Version = 1
+		r.db.reservations.insert(&row.ID, &row)
+		reservation.ID = row.ID
+		reservation.Version = 1
+		return nil
+	}
+	if stored, ok := r.db.reservations.get(row.ID); !ok || stored.Version != row.Version {
+		return ErrVersionConflict
+	}
+	row.Version++
+	r.db.reservations.put(row.ID, row)
+	reservation.Version = row.Version
+	return nil
+}
+
+func (r *memoryReservationRepository) Delete(id, version uint) error {
+	defer r.db.lock()()
+	if stored, ok := r.db.reservations.get(id); !ok || stored.Version != version {
+		return ErrVersionConflict
+	}
+	moveRow(r.db.reservations, r.db.deletedReservations, id, func(reservation *models.Reservation) {
+		reservation.DeletedAt = deletedNow()
+		reservation.Version++
+	})
+	return nil
+}
+
+func (r *memoryReservationRepository) Restore(id uint) error {
+	defer r.db.lock()()
+	if !moveRow(r.db.deletedReservations, r.db.reservations, id, func(reservation *models.Reservation) {
+		reservation.DeletedAt = gorm.DeletedAt{}
+		reservation.Version++
+	}) {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *memoryReservationRepository) FindDeletedBefore(before time.Time) ([]models.Reservation, error) {
+	defer r.db.lock()()
+	var reservations []models.Reservation
+	for _, reservation := range r.db.deletedReservations.all() {
+		if reservation.DeletedAt.Time.Before(before) {
+			reservations = append(reservations, reservation)
+		}
+	}
+	return reservations, nil
+}
+
+func (r *memoryReservationRepository) Purge(id uint) error {
+	defer r.db.lock()()
+	if _, ok := r.db.deletedReservations.get(id); !ok {
+		return nil
+	}
+	r.deletePricing(id)
+	r.deleteSegments(id)
+	for _, event := range r.db.reservationEvents.all() {
+		if event.ReservationID == id {
+			r.db.reservationEvents.delete(event.ID)
+		}
+	}
+	r.db.deletedReservations.delete(id)
+	return nil
+}
+
+func (r *memoryReservationRepository) ExistsInRoom(roomID uint) (bool, error) {
+	defer r.db.lock()()
+	for _, reservation := range append(r.db.reservations.all(), r.db.deletedReservations.all()...) {
+		if reservation.RoomID == roomID {
+			return true, nil
+		}
+	}
+	for _, segment := range r.db.segments.all() {
+		if segment.RoomID == roomID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryReservationRepository) ExistsForUser(userID uint) (bool, error) {
+	defer r.db.lock()()
+	for _, reservation := range append(r.db.reservations.all(), r.db.deletedReservations.all()...) {
+		if reservation.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryReservationRepository) deletePricing(reservationID uint) {
+	for _, night := range r.db.reservationNights.all() {
+		if night.ReservationID == reservationID {
+			r.db.reservationNights.delete(night.ID)
+		}
+	}
+	for _, tax := range r.db.reservationTaxes.all() {
+		if tax.ReservationID == reservationID {
+			r.db.reservationTaxes.delete(tax.ID)
+		}
+	}
+}
+
+func (r *memoryReservationRepository) ReplacePricing(reservationID uint, nights []models.ReservationNight, taxes []models.ReservationTax) error {
+	defer r.db.lock()()
+	r.deletePricing(reservationID)
+	r.insertNights(reservationID, nights)
+	r.insertTaxes(reservationID, taxes)
+	return nil
+}
+
+func (r *memoryReservationRepository) deleteSegments(reservationID uint) {
+	for _, segment := range r.db.segments.all() {
+		if segment.ReservationID == reservationID {
+			r.db.segments.delete(segment.ID)
+		}
+	}
+}
+
+func (r *memoryReservationRepository) ReplaceSegments(reservationID uint, segments []models.ReservationSegment) error {
+	defer r.db.lock()()
+	r.deleteSegments(reservationID)
+	r.insertSegments(reservationID, segments)
+	return nil
+}
+
+// revenueReservations returns the reservations matching the report filter
+// by ID.
+func (r *memoryReservationRepository) revenueReservations(start, end time.Time, statuses []string) map[uint]models.Reservation {
+	matching := make(map[uint]models.Reservation)
+	for _, reservation := range append(r.db.reservations.all(), r.db.deletedReservations.all()...) {
+		if reservation.StartDate.Before(start) || reservation.EndDate.After(end) || !contains(statuses, reservation.Status) {
+			continue
+		}
+		matching[reservation.ID] = reservation
+	}
+	return matching
+}
+
+// RevenueRows mirrors the joins of the GORM implementation.
+func (r *memoryReservationRepository) RevenueRows(start, end time.Time, statuses []string) ([]RevenueRow, error) {
+	defer r.db.lock()()
+	matching := r.revenueReservations(start, end, statuses)
+	var rows []RevenueRow
+	for _, night := range r.db.reservationNights.all() {
+		if reservation, ok := matching[night.ReservationID]; ok {
+			rows = append(rows, RevenueRow{Date: night.Date, Amount: night.Amount, Currency: reservation.Currency, BookedAt: reservation.CreatedAt})
+		}
+	}
+	var taxes []revenueTax
+	for _, tax := range r.db.reservationTaxes.all() {
+		if reservation, ok := matching[tax.ReservationID]; ok {
+			taxes = append(taxes, revenueTax{Date: tax.Date, Amount: tax.Amount, Inclusive: tax.Inclusive, Currency: reservation.Currency, BookedAt: reservation.CreatedAt})
+		}
+	}
+	return append(rows, taxRows(taxes)...), nil
+}
+
+// revenueTax is a stored tax joined with its reservation.
+type revenueTax struct {
+	Date      time.Time
+	Amount    int64
+	Inclusive bool
+	Currency  string
+	BookedAt  time.Time
+}
+
+// taxRows turns stored taxes into revenue rows. An inclusive tax is already
+// part of the night amount, so it only adds to the tax share.
+func taxRows(taxes []revenueTax) []RevenueRow {
+	rows := make([]RevenueRow, 0, len(taxes))
+	for _, tax := range taxes {
+		row := RevenueRow{Date: tax.Date, Tax: tax.Amount, Currency: tax.Currency, BookedAt: tax.BookedAt}
+		if !tax.Inclusive {
+			row.Amount = tax.Amount
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
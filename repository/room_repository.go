@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoomRepository interface {
+	Create(room *models.Room) error
+	FindByID(id uint) (*models.Room, error)
+	FindByNumber(number string) (*models.Room, error)
+	// FindByIDForUpdate loads a room and locks its row until the surrounding
+	// transaction ends, serializing bookings of the same room.
+	FindByIDForUpdate(id uint) (*models.Room, error)
+	FindAll() ([]models.Room, error)
+	// FindByRoomType returns the rooms of a room type ordered by number.
+	FindByRoomType(roomTypeID uint) ([]models.Room, error)
+	Count() (int64, error)
+	Save(room *models.Room) error
+	// Delete marks a room at version deleted, hiding it from every other
+	// query, and increments its Version. It returns ErrVersionConflict when
+	// the room changed or is gone.
+	Delete(id, version uint) error
+	// Restore undoes Delete and increments the Version of the room.
+	Restore(id uint) error
+	// FindDeletedBefore returns the rooms deleted before the given time.
+	FindDeletedBefore(before time.Time) ([]models.Room, error)
+	// Purge removes a deleted room for good, with its housekeeping tasks.
+	Purge(id uint) error
+}
+
+type gormRoomRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRoomRepository) Create(room *models.Room) error {
+	if err := r.checkUnique(room); err != nil {
+		return err
+	}
+	room.Version = 1
+	return r.db.Create(room).Error
+}
+
+func (r *gormRoomRepository) FindByID(id uint) (*models.Room, error) {
+	var room models.Room
+	if err := r.db.First(&room, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &room, nil
+}
+
+func (r *gormRoomRepository) FindByNumber(number string) (*models.Room, error) {
+	var room models.Room
+	if err := r.db.Where("number = ?", number).First(&room).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &room, nil
+}
+
+func (r *gormRoomRepository) FindByIDForUpdate(id uint) (*models.Room, error) {
+	var room models.Room
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&room, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &room, nil
+}
+
+func (r *gormRoomRepository) FindAll() ([]models.Room, error) {
+	var rooms []models.Room
+	if err := r.db.Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *gormRoomRepository) FindByRoomType(roomTypeID uint) ([]models.Room, error) {
+	var rooms []models.Room
+	if err := r.db.Where("room_type_id = ?", roomTypeID).Order("number").Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *gormRoomRepository) Count() (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Room{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *gormRoomRepository) Save(room *models.Room) error {
+	if room.ID == 0 {
+		return r.Create(room)
+	}
+	if err := r.checkUnique(room); err != nil {
+		return err
+	}
+	return saveVersioned(r.db, room, &room.Version)
+}
+
+// checkUnique reports a clash with the unique index on the room number
+// before the database does, telling deleted rooms apart.
+func (r *gormRoomRepository) checkUnique(room *models.Room) error {
+	return checkUnique(r.db, &models.Room{}, room.ID, "number = ?", room.Number)
+}
+
+func (r *gormRoomRepository) Delete(id, version uint) error {
+	return deleteVersioned(r.db, &models.Room{}, id, version)
+}
+
+func (r *gormRoomRepository) Restore(id uint) error {
+	return restoreDeleted(r.db, &models.Room{}, id)
+}
+
+func (r *gormRoomRepository) FindDeletedBefore(before time.Time) ([]models.Room, error) {
+	var rooms []models.Room
+	if err := r.db.Unscoped().Where("deleted_at < ?", before).Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *gormRoomRepository) Purge(id uint) error {
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.Room{}, id)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+	return r.db.Where("room_id = ?", id).Delete(&models.HousekeepingTask{}).Error
+}
+
+type memoryRoomRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryRoomRepository) Create(room *models.Room) error {
+	defer r.db.lock()()
+	if err := r.checkUnique(room); err != nil {
+		return err
+	}
+	room.Version = 1
+	r.db.rooms.insert(&room.ID, room)
+	return nil
+}
+
+func (r *memoryRoomRepository) FindByID(id uint) (*models.Room, error) {
+	defer r.db.lock()()
+	room, ok := r.db.rooms.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &room, nil
+}
+
+func (r *memoryRoomRepository) FindByNumber(number string) (*models.Room, error) {
+	defer r.db.lock()()
+	for _, room := range r.db.rooms.all() {
+		if room.Number == number {
+			return &room, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// FindByIDForUpdate needs no row lock because memory transactions already
+// hold the store mutex.
+func (r *memoryRoomRepository) FindByIDForUpdate(id uint) (*models.Room, error) {
+	return r.FindByID(id)
+}
+
+func (r *memoryRoomRepository) FindAll() ([]models.Room, error) {
+	defer r.db.lock()()
+	return r.db.rooms.all(), nil
+}
+
+func (r *memoryRoomRepository) FindByRoomType(roomTypeID uint) ([]models.Room, error) {
+	defer r.db.lock()()
+	var rooms []models.Room
+	for _, room := range r.db.rooms.all() {
+		if room.RoomTypeID == roomTypeID {
+			rooms = append(rooms, room)
+		}
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i].Number < rooms[j].Number })
+	return rooms, nil
+}
+
+func (r *memoryRoomRepository) Count() (int64, error) {
+	defer r.db.lock()()
+	return int64(len(r.db.rooms.rows)), nil
+}
+
+func (r *memoryRoomRepository) Save(room *models.Room) error {
+	defer r.db.lock()()
+	if err := r.checkUnique(room); err != nil {
+		return err
+	}
+	if room.ID == 0 {
+		room.Version = 1
+		r.db.rooms.insert(&room.ID, room)
+		return nil
+	}
+	if stored, ok := r.db.rooms.get(room.ID); !ok || stored.Version != room.Version {
+		return ErrVersionConflict
+	}
+	room.Version++
+	r.db.rooms.put(room.ID, *room)
+	return nil
+}
+
+func (r *memoryRoomRepository) Delete(id, version uint) error {
+	defer r.db.lock()()
+	if stored, ok := r.db.rooms.get(id); !ok || stored.Version != version {
+		return ErrVersionConflict
+	}
+	moveRow(r.db.rooms, r.db.deletedRooms, id, func(room *models.Room) {
+		room.DeletedAt = deletedNow()
+		room.Version++
+	})
+	return nil
+}
+
+func (r *memoryRoomRepository) Restore(id uint) error {
+	defer r.db.lock()()
+	if !moveRow(r.db.deletedRooms, r.db.rooms, id, func(room *models.Room) {
+		room.DeletedAt = gorm.DeletedAt{}
+		room.Version++
+	}) {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *memoryRoomRepository) FindDeletedBefore(before time.Time) ([]models.Room, error) {
+	defer r.db.lock()()
+	var rooms []models.Room
+	for _, room := range r.db.deletedRooms.all() {
+		if room.DeletedAt.Time.Before(before) {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+func (r *memoryRoomRepository) Purge(id uint) error {
+	defer r.db.lock()()
+	if _, ok := r.db.deletedRooms.get(id); !ok {
+		return nil
+	}
+	for _, task := range r.db.housekeepingTasks.all() {
+		if task.RoomID == id {
+			r.db.housekeepingTasks.delete(task.ID)
+		}
+	}
+	r.db.deletedRooms.delete(id)
+	return nil
+}
+
+// checkUnique mirrors the unique index on the room number, which deleted
+// rooms still take part in.
+func (r *memoryRoomRepository) checkUnique(room *models.Room) error {
+	for _, other := range r.db.rooms.all() {
+		if other.ID != room.ID && other.Number == room.Number {
+			return ErrDuplicate
+		}
+	}
+	for _, other := range r.db.deletedRooms.all() {
+		if other.ID != room.ID && other.Number == room.Number {
+			return ErrDeletedDuplicate
+		}
+	}
+	return nil
+}
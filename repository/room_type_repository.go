@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+
+	"gorm.io/gorm"
+)
+
+// RoomTypeRepository stores the catalog of room types rooms are sold as.
+type RoomTypeRepository interface {
+	Create(roomType *models.RoomType) error
+	FindByID(id uint) (*models.RoomType, error)
+	FindByName(name string) (*models.RoomType, error)
+	FindAll() ([]models.RoomType, error)
+	Save(roomType *models.RoomType) error
+	Delete(id uint) error
+}
+
+type gormRoomTypeRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRoomTypeRepository) Create(roomType *models.RoomType) error {
+	return r.db.Create(roomType).Error
+}
+
+func (r *gormRoomTypeRepository) FindByID(id uint) (*models.RoomType, error) {
+	var roomType models.RoomType
+	if err := r.db.First(&roomType, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &roomType, nil
+}
+
+func (r *gormRoomTypeRepository) FindByName(name string) (*models.RoomType, error) {
+	var roomType models.RoomType
+	if err := r.db.Where("name = ?", name).First(&roomType).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &roomType, nil
+}
+
+func (r *gormRoomTypeRepository) FindAll() ([]models.RoomType, error) {
+	var roomTypes []models.RoomType
+	if err := r.db.Order("id").Find(&roomTypes).Error; err != nil {
+		return nil, err
+	}
+	return roomTypes, nil
+}
+
+func (r *gormRoomTypeRepository) Save(roomType *models.RoomType) error {
+	return r.db.Save(roomType).Error
+}
+
+func (r *gormRoomTypeRepository) Delete(id uint) error {
+	return r.db.Delete(&models.RoomType{}, id).Error
+}
+
+type memoryRoomTypeRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryRoomTypeRepository) Create(roomType *models.RoomType) error {
+	defer r.db.lock()()
+	if err := r.checkUnique(roomType); err != nil {
+		return err
+	}
+	r.db.roomTypes.insert(&roomType.ID, roomType)
+	return nil
+}
+
+func (r *memoryRoomTypeRepository) FindByID(id uint) (*models.RoomType, error) {
+	defer r.db.lock()()
+	roomType, ok := r.db.roomTypes.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &roomType, nil
+}
+
+func (r *memoryRoomTypeRepository) FindByName(name string) (*models.RoomType, error) {
+	defer r.db.lock()()
+	for _, roomType := range r.db.roomTypes.all() {
+		if roomType.Name == name {
+			return &roomType, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryRoomTypeRepository) FindAll() ([]models.RoomType, error) {
+	defer r.db.lock()()
+	return r.db.roomTypes.all(), nil
+}
+
+func (r *memoryRoomTypeRepository) Save(roomType *models.RoomType) error {
+	defer r.db.lock()()
+	if err := r.checkUnique(roomType); err != nil {
+		return err
+	}
+	if roomType.ID == 0 {
+		r.db.roomTypes.insert(&roomType.ID, roomType)
+		return nil
+	}
+	r.db.roomTypes.put(roomType.ID, *roomType)
+	return nil
+}
+
+func (r *memoryRoomTypeRepository) Delete(id uint) error {
+	defer r.db.lock()()
+	r.db.roomTypes.delete(id)
+	return nil
+}
+
+// checkUnique mirrors the unique index on the room type name.
+func (r *memoryRoomTypeRepository) checkUnique(roomType *models.RoomType) error {
+	for _, other := range r.db.roomTypes.all() {
+		if other.ID != roomType.ID && other.Name == roomType.Name {
+			return ErrDuplicate
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when a lookup matches no record.
+var ErrNotFound = errors.New("record not found")
+
+// ErrDuplicate is returned when a write violates a uniqueness constraint.
+var ErrDuplicate = errors.New("duplicate record")
+
+// ErrDeletedDuplicate is the ErrDuplicate returned when the record holding
+// the unique value is a deleted user or room, which has to be restored
+// rather than created again.
+var ErrDeletedDuplicate = fmt.Errorf("%w: the record holding the value is deleted", ErrDuplicate)
+
+// ErrVersionConflict is returned when a save of a user, room or reservation
+// carries a Version other than the stored one, i.e. the record changed since
+// it was read. A successful save increments the Version.
+var ErrVersionConflict = errors.New("record was changed since it was read")
+
+// Store groups the repositories the HTTP handlers depend on so that they can
+// run against either a database or an in-memory backend.
+type Store interface {
+	Users() UserRepository
+	Rooms() RoomRepository
+	RoomTypes() RoomTypeRepository
+	Reservations() ReservationRepository
+	ReservationEvents() ReservationEventRepository
+	HousekeepingTasks() HousekeepingTaskRepository
+	Rates() RateRepository
+	Folios() FolioRepository
+	Payments() PaymentRepository
+	Invoices() InvoiceRepository
+	Taxes() TaxRepository
+	ExchangeRates() ExchangeRateRepository
+
+	// Transaction runs fn against a Store whose repositories all share one
+	// transaction. It commits if fn returns nil and rolls back otherwise.
+	Transaction(fn func(tx Store) error) error
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+
+	"gorm.io/gorm"
+)
+
+// TaxRepository stores the taxes and fees applied when pricing a stay.
+type TaxRepository interface {
+	Create(tax *models.Tax) error
+	FindByID(id uint) (*models.Tax, error)
+	FindAll() ([]models.Tax, error)
+	// FindActive returns the taxes currently charged on new bookings.
+	FindActive() ([]models.Tax, error)
+	Save(tax *models.Tax) error
+	Delete(id uint) error
+}
+
+type gormTaxRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormTaxRepository) Create(tax *models.Tax) error {
+	return r.db.Create(tax).Error
+}
+
+func (r *gormTaxRepository) FindByID(id uint) (*models.Tax, error) {
+	var tax models.Tax
+	if err := r.db.First(&tax, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &tax, nil
+}
+
+func (r *gormTaxRepository) FindAll() ([]models.Tax, error) {
+	var taxes []models.Tax
+	if err := r.db.Order("id").Find(&taxes).Error; err != nil {
+		return nil, err
+	}
+	return taxes, nil
+}
+
+func (r *gormTaxRepository) FindActive() ([]models.Tax, error) {
+	var taxes []models.Tax
+	if err := r.db.Where("active = ?", true).Order("id").Find(&taxes).Error; err != nil {
+		return nil, err
+	}
+	return taxes, nil
+}
+
+func (r *gormTaxRepository) Save(tax *models.Tax) error {
+	return r.db.Save(tax).Error
+}
+
+func (r *gormTaxRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Tax{}, id).Error
+}
+
+type memoryTaxRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryTaxRepository) Create(tax *models.Tax) error {
+	defer r.db.lock()()
+	if err := r.checkUnique(tax); err != nil {
+		return err
+	}
+	r.db.taxes.insert(&tax.ID, tax)
+	return nil
+}
+
+func (r *memoryTaxRepository) FindByID(id uint) (*models.Tax, error) {
+	defer r.db.lock()()
+	tax, ok := r.db.taxes.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &tax, nil
+}
+
+func (r *memoryTaxRepository) FindAll() ([]models.Tax, error) {
+	defer r.db.lock()()
+	return r.db.taxes.all(), nil
+}
+
+func (r *memoryTaxRepository) FindActive() ([]models.Tax, error) {
+	defer r.db.lock()()
+	var taxes []models.Tax
+	for _, tax := range r.db.taxes.all() {
+		if tax.Active {
+			taxes = append(taxes, tax)
+		}
+	}
+	return taxes, nil
+}
+
+func (r *memoryTaxRepository) Save(tax *models.Tax) error {
+	defer r.db.lock()()
+	if err := r.checkUnique(tax); err != nil {
+		return err
+	}
+	if tax.ID == 0 {
+		r.db.taxes.insert(&tax.ID, tax)
+		return nil
+	}
+	r.db.taxes.put(tax.ID, *tax)
+	return nil
+}
+
+func (r *memoryTaxRepository) Delete(id uint) error {
+	defer r.db.lock()()
+	r.db.taxes.delete(id)
+	return nil
+}
+
+// checkUnique mirrors the unique index on the tax code.
+func (r *memoryTaxRepository) checkUnique(tax *models.Tax) error {
+	for _, other := range r.db.taxes.all() {
+		if other.ID != tax.ID && other.Code == tax.Code {
+			return ErrDuplicate
+		}
+	}
+	return nil
+}
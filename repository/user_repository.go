@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"hotel_management_system/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserRepository interface {
+	Create(user *models.User) error
+	FindByID(id uint) (*models.User, error)
+	// FindByIDForUpdate loads a user and locks its row until the
+	// surrounding transaction ends.
+	FindByIDForUpdate(id uint) (*models.User, error)
+	FindByUsername(username string) (*models.User, error)
+	FindByRole(role string) ([]models.User, error)
+	FindAll() ([]models.User, error)
+	Save(user *models.User) error
+	// Delete marks a user at version deleted, hiding it from every other
+	// query, and increments its Version. It returns ErrVersionConflict when
+	// the user changed or is gone.
+	Delete(id, version uint) error
+	// Restore undoes Delete and increments the Version of the user.
+	Restore(id uint) error
+	// FindDeletedBefore returns the users deleted before the given time.
+	FindDeletedBefore(before time.Time) ([]models.User, error)
+	// Purge removes a deleted user for good.
+	Purge(id uint) error
+}
+
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormUserRepository) Create(user *models.User) error {
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+	user.Version = 1
+	return r.db.Create(user).Error
+}
+
+func (r *gormUserRepository) FindByID(id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.First(&user, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindByIDForUpdate(id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindByUsername(username string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindByRole(role string) ([]models.User, error) {
+	var users []models.User
+	if err := r.db.Where("role = ?", role).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *gormUserRepository) FindAll() ([]models.User, error) {
+	var users []models.User
+	if err := r.db.Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *gormUserRepository) Save(user *models.User) error {
+	if user.ID == 0 {
+		return r.Create(user)
+	}
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+	return saveVersioned(r.db, user, &user.Version)
+}
+
+// checkUnique reports a clash with the unique indexes on username and email
+// before the database does, telling deleted users apart.
+func (r *gormUserRepository) checkUnique(user *models.User) error {
+	return checkUnique(r.db, &models.User{}, user.ID, "username = ? OR email = ?", user.Username, user.Email)
+}
+
+func (r *gormUserRepository) Delete(id, version uint) error {
+	return deleteVersioned(r.db, &models.User{}, id, version)
+}
+
+func (r *gormUserRepository) Restore(id uint) error {
+	return restoreDeleted(r.db, &models.User{}, id)
+}
+
+func (r *gormUserRepository) FindDeletedBefore(before time.Time) ([]models.User, error) {
+	var users []models.User
+	if err := r.db.Unscoped().Where("deleted_at < ?", before).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *gormUserRepository) Purge(id uint) error {
+	return r.db.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.User{}, id).Error
+}
+
+type memoryUserRepository struct {
+	db *memoryDB
+}
+
+func (r *memoryUserRepository) Create(user *models.User) error {
+	defer r.db.lock()()
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+	user.Version = 1
+	r.db.users.insert(&user.ID, user)
+	return nil
+}
+
+func (r *memoryUserRepository) FindByID(id uint) (*models.User, error) {
+	defer r.db.lock()()
+	user, ok := r.db.users.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+// FindByIDForUpdate needs no row lock because memory transactions already
+// hold the store mutex.
+func (r *memoryUserRepository) FindByIDForUpdate(id uint) (*models.User, error) {
+	return r.FindByID(id)
+}
+
+func (r *memoryUserRepository) FindByUsername(username string) (*models.User, error) {
+	defer r.db.lock()()
+	for _, user := range r.db.users.all() {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryUserRepository) FindByRole(role string) ([]models.User, error) {
+	defer r.db.lock()()
+	var users []models.User
+	for _, user := range r.db.users.all() {
+		if user.Role == role {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *memoryUserRepository) FindAll() ([]models.User, error) {
+	defer r.db.lock()()
+	return r.db.users.all(), nil
+}
+
+func (r *memoryUserRepository) Save(user *models.User) error {
+	defer r.db.lock()()
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+	if user.ID == 0 {
+		user.Version = 1
+		r.db.users.insert(&user.ID, user)
+		return nil
+	}
+	if stored, ok := r.db.users.get(user.ID); !ok || stored.Version != user.Version {
+		return ErrVersionConflict
+	}
+	user.Version++
+	r.db.users.put(user.ID, *user)
+	return nil
+}
+
+func (r *memoryUserRepository) Delete(id, version uint) error {
+	defer r.db.lock()()
+	if stored, ok := r.db.users.get(id); !ok || stored.Version != version {
+		return ErrVersionConflict
+	}
+	moveRow(r.db.users, r.db.deletedUsers, id, func(user *models.User) {
+		user.DeletedAt = deletedNow()
+		user.Version++
+	})
+	return nil
+}
+
+func (r *memoryUserRepository) Restore(id uint) error {
+	defer r.db.lock()()
+	if !moveRow(r.db.deletedUsers, r.db.users, id, func(user *models.User) {
+		user.DeletedAt = gorm.DeletedAt{}
+		user.Version++
+	}) {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *memoryUserRepository) FindDeletedBefore(before time.Time) ([]models.User, error) {
+	defer r.db.lock()()
+	var users []models.User
+	for _, user := range r.db.deletedUsers.all() {
+		if user.DeletedAt.Time.Before(before) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *memoryUserRepository) Purge(id uint) error {
+	defer r.db.lock()()
+	r.db.deletedUsers.delete(id)
+	return nil
+}
+
+// checkUnique mirrors the unique indexes on username and email, which
+// deleted users still take part in.
+func (r *memoryUserRepository) checkUnique(user *models.User) error {
+	clashes := func(other models.User) bool {
+		return other.ID != user.ID && (other.Username == user.Username || other.Email == user.Email)
+	}
+	for _, other := range r.db.users.all() {
+		if clashes(other) {
+			return ErrDuplicate
+		}
+	}
+	for _, other := range r.db.deletedUsers.all() {
+		if clashes(other) {
+			return ErrDeletedDuplicate
+		}
+	}
+	return nil
+}
@@ -9,37 +9,80 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-func InitRouter() *mux.Router {
+func InitRouter(h *controllers.Handler) *mux.Router {
 	r := mux.NewRouter()
 
-	r.HandleFunc("/register", controllers.RegisterHandler).Methods("POST")
-	r.HandleFunc("/login", controllers.LoginHandler).Methods("POST")
-	r.Handle("/customers", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetCustomers)))).Methods("GET")
-	r.Handle("/users/{user_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetUser)))).Methods("GET")
-	r.Handle("/users/{user_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.UpdateUser)))).Methods("PUT")
-	r.Handle("/users/{user_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.DeleteUser)))).Methods("DELETE")
-	r.Handle("/users", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(controllers.GetAllUsers)))).Methods("GET")
-	r.Handle("/profile", middleware.JWTAuth(http.HandlerFunc(controllers.GetProfile))).Methods("GET")
-	r.Handle("/profile", middleware.JWTAuth(http.HandlerFunc(controllers.UpdateProfile))).Methods("PUT")
-	r.Handle("/profile/password", middleware.JWTAuth(http.HandlerFunc(controllers.UpdatePassword))).Methods("PUT")
-
-	r.Handle("/rooms", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.CreateRoom)))).Methods("POST")
-	r.Handle("/rooms/{room_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.UpdateRoom)))).Methods("PUT")
-	r.Handle("/rooms/{room_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.DeleteRoom)))).Methods("DELETE")
-	r.Handle("/rooms", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetRooms)))).Methods("GET")
-	r.Handle("/rooms/{room_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetRoomDetails)))).Methods("GET")
-
-	r.Handle("/reservations", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.CreateReservation)))).Methods("POST")
-	r.Handle("/reservations/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.UpdateReservation)))).Methods("PUT")
-	r.Handle("/reservations/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.DeleteReservation)))).Methods("DELETE")
-	r.Handle("/reservations", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetReservations)))).Methods("GET")
-	r.Handle("/reservations/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetReservationDetails)))).Methods("GET")
-	r.Handle("/reservations/status/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.UpdateReservationStatus)))).Methods("PUT")
-
-	r.Handle("/occupancy", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.Occupancy)))).Methods("POST")
-	r.Handle("/revenue", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetTotalRevenue)))).Methods("POST")
-	r.Handle("/revenue/daily", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetDailyRevenue)))).Methods("POST")
-	r.Handle("/revenue/monthly", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(controllers.GetMonthlyRevenue)))).Methods("POST")
+	r.HandleFunc("/register", h.RegisterHandler).Methods("POST")
+	r.HandleFunc("/login", h.LoginHandler).Methods("POST")
+	r.Handle("/customers", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetCustomers)))).Methods("GET")
+	r.Handle("/users/{user_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.ETag(h.UserVersion)(http.HandlerFunc(h.GetUser))))).Methods("GET")
+	r.Handle("/users/{user_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.IfMatch(h.UserVersion)(http.HandlerFunc(h.UpdateUser))))).Methods("PUT")
+	r.Handle("/users/{user_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.IfMatch(h.UserVersion)(http.HandlerFunc(h.DeleteUser))))).Methods("DELETE")
+	r.Handle("/users/{user_id}/restore", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.RestoreUser)))).Methods("POST")
+	r.Handle("/users", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.GetAllUsers)))).Methods("GET")
+	r.Handle("/profile", middleware.JWTAuth(middleware.ETag(h.ProfileVersion)(http.HandlerFunc(h.GetProfile)))).Methods("GET")
+	r.Handle("/profile", middleware.JWTAuth(middleware.IfMatch(h.ProfileVersion)(http.HandlerFunc(h.UpdateProfile)))).Methods("PUT")
+	r.Handle("/profile/password", middleware.JWTAuth(middleware.IfMatch(h.ProfileVersion)(http.HandlerFunc(h.UpdatePassword)))).Methods("PUT")
+
+	r.Handle("/rooms", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.CreateRoom)))).Methods("POST")
+	r.Handle("/rooms/{room_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.IfMatch(h.RoomVersion)(http.HandlerFunc(h.UpdateRoom))))).Methods("PUT")
+	r.Handle("/rooms/{room_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.IfMatch(h.RoomVersion)(http.HandlerFunc(h.DeleteRoom))))).Methods("DELETE")
+	r.Handle("/rooms/{room_id}/restore", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.RestoreRoom)))).Methods("POST")
+	r.Handle("/rooms", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetRooms)))).Methods("GET")
+	r.Handle("/rooms/{room_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.ETag(h.RoomVersion)(http.HandlerFunc(h.GetRoomDetails))))).Methods("GET")
+	r.Handle("/room-types", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetRoomTypes)))).Methods("GET")
+	r.Handle("/room-types", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.CreateRoomType)))).Methods("POST")
+	r.Handle("/room-types/{room_type_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetRoomType)))).Methods("GET")
+	r.Handle("/room-types/{room_type_id}", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.UpdateRoomType)))).Methods("PUT")
+	r.Handle("/room-types/{room_type_id}", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.DeleteRoomType)))).Methods("DELETE")
+
+	r.Handle("/reservations", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.CreateReservation)))).Methods("POST")
+	r.Handle("/reservations/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.IfMatch(h.ReservationVersion)(http.HandlerFunc(h.UpdateReservation))))).Methods("PUT")
+	r.Handle("/reservations/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.IfMatch(h.ReservationVersion)(http.HandlerFunc(h.PatchReservation))))).Methods("PATCH")
+	r.Handle("/reservations/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.IfMatch(h.ReservationVersion)(http.HandlerFunc(h.DeleteReservation))))).Methods("DELETE")
+	r.Handle("/reservations/{reservation_id}/restore", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.RestoreReservation)))).Methods("POST")
+	r.Handle("/reservations", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetReservations)))).Methods("GET")
+	r.Handle("/reservations/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.ETag(h.ReservationVersion)(http.HandlerFunc(h.GetReservationDetails))))).Methods("GET")
+	r.Handle("/reservations/status/{reservation_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(middleware.IfMatch(h.ReservationVersion)(http.HandlerFunc(h.UpdateReservationStatus))))).Methods("PUT")
+	r.Handle("/reservations/{reservation_id}/check-in", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.CheckInReservation)))).Methods("POST")
+	r.Handle("/reservations/{reservation_id}/check-out", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.CheckOutReservation)))).Methods("POST")
+	r.Handle("/reservations/{reservation_id}/move", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.MoveReservation)))).Methods("POST")
+	r.Handle("/reservations/{reservation_id}/cancel", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.CancelReservation)))).Methods("POST")
+	r.Handle("/reservations/{reservation_id}/cancel/preview", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.PreviewCancellation)))).Methods("GET")
+	r.Handle("/reservations/{reservation_id}/history", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetReservationHistory)))).Methods("GET")
+	r.Handle("/reservations/{reservation_id}/folio", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetReservationFolio)))).Methods("GET")
+	r.Handle("/reservations/{reservation_id}/charges", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.PostReservationCharge)))).Methods("POST")
+	r.Handle("/reservations/{reservation_id}/payments", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetReservationPayments)))).Methods("GET")
+	r.Handle("/reservations/{reservation_id}/payments", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.CreateReservationPayment)))).Methods("POST")
+	r.Handle("/reservations/{reservation_id}/invoice", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetReservationInvoice)))).Methods("GET")
+	r.Handle("/invoices/{invoice_id}", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetInvoice)))).Methods("GET")
+
+	r.Handle("/room-assignments/optimize", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.OptimizeRoomAssignments)))).Methods("POST")
+
+	r.Handle("/availability", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.SearchAvailability)))).Methods("GET")
+
+	r.Handle("/housekeeping/tasks", middleware.JWTAuth(middleware.Authorize("admin", "receptionist", "housekeeper")(http.HandlerFunc(h.GetHousekeepingTasks)))).Methods("GET")
+	r.Handle("/housekeeping/tasks/{task_id}/claim", middleware.JWTAuth(middleware.Authorize("admin", "housekeeper")(http.HandlerFunc(h.ClaimHousekeepingTask)))).Methods("PUT")
+	r.Handle("/housekeeping/tasks/{task_id}/complete", middleware.JWTAuth(middleware.Authorize("admin", "housekeeper")(http.HandlerFunc(h.CompleteHousekeepingTask)))).Methods("PUT")
+	r.Handle("/housekeeping/board", middleware.JWTAuth(middleware.Authorize("admin", "receptionist", "housekeeper")(http.HandlerFunc(h.GetHousekeepingBoard)))).Methods("GET")
+
+	r.Handle("/rate-plans", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetRatePlans)))).Methods("GET")
+	r.Handle("/rate-plans", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.CreateRatePlan)))).Methods("POST")
+	r.Handle("/rate-plans/{plan_id}", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.UpdateRatePlan)))).Methods("PUT")
+	r.Handle("/rate-overrides", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetRateOverrides)))).Methods("GET")
+	r.Handle("/rate-overrides", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.CreateRateOverride)))).Methods("POST")
+	r.Handle("/rate-overrides/{override_id}", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.DeleteRateOverride)))).Methods("DELETE")
+	r.Handle("/taxes", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetTaxes)))).Methods("GET")
+	r.Handle("/taxes", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.CreateTax)))).Methods("POST")
+	r.Handle("/taxes/{tax_id}", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.UpdateTax)))).Methods("PUT")
+	r.Handle("/taxes/{tax_id}", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.DeleteTax)))).Methods("DELETE")
+	r.Handle("/exchange-rates", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetExchangeRates)))).Methods("GET")
+	r.Handle("/exchange-rates", middleware.JWTAuth(middleware.Authorize("admin")(http.HandlerFunc(h.UploadExchangeRates)))).Methods("POST")
+
+	r.Handle("/occupancy", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.Occupancy)))).Methods("POST")
+	r.Handle("/revenue", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetTotalRevenue)))).Methods("POST")
+	r.Handle("/revenue/daily", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetDailyRevenue)))).Methods("POST")
+	r.Handle("/revenue/monthly", middleware.JWTAuth(middleware.Authorize("admin", "receptionist")(http.HandlerFunc(h.GetMonthlyRevenue)))).Methods("POST")
 
 	// Swagger endpoint
 	r.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"sort"
+	"time"
+)
+
+// BlockingStatuses are the reservation statuses that hold a room. Cancelled,
+// no-show and checked-out reservations release it.
+var BlockingStatuses = []string{"pending", "confirmed", "checked-in"}
+
+var (
+	ErrInvalidDateRange = errors.New("end date must be after start date")
+	ErrRoomUnavailable  = errors.New("room is not available for the requested dates")
+	ErrRoomOutOfService = errors.New("room is out of service")
+	ErrUserNotFound     = errors.New("the guest of the reservation does not exist")
+	ErrRoomNotFound     = errors.New("room does not exist")
+)
+
+// lockRoom locks the room with the given ID until the transaction of tx
+// ends. A room that does not exist is ErrRoomNotFound, which tells it apart
+// from a missing reservation.
+func lockRoom(tx repository.Store, id uint) (*models.Room, error) {
+	room, err := tx.Rooms().FindByIDForUpdate(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrRoomNotFound
+	}
+	return room, err
+}
+
+// IsBlocking reports whether a reservation in the given status holds its room.
+func IsBlocking(status string) bool {
+	for _, s := range BlockingStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAvailability returns ErrRoomUnavailable when a blocking reservation
+// other than excludeID stays in the room during [start, end).
+func CheckAvailability(store repository.Store, roomID uint, start, end time.Time, excludeID uint) error {
+	if !end.After(start) {
+		return ErrInvalidDateRange
+	}
+
+	reservations, err := store.Reservations().FindOverlappingRoom(roomID, start, end, BlockingStatuses)
+	if err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		if reservation.ID == excludeID {
+			continue
+		}
+		for _, stay := range roomStays(&reservation) {
+			if stay.RoomID == roomID && stay.StartDate.Before(end) && stay.EndDate.After(start) {
+				return ErrRoomUnavailable
+			}
+		}
+	}
+	return nil
+}
+
+// OccupiedRooms returns the IDs of the rooms held by a blocking reservation
+// at some point in [start, end).
+func OccupiedRooms(store repository.Store, start, end time.Time) (map[uint]bool, error) {
+	reservations, err := store.Reservations().FindOverlapping(start, end, BlockingStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	occupied := make(map[uint]bool)
+	for _, reservation := range reservations {
+		for _, stay := range roomStays(&reservation) {
+			if stay.StartDate.Before(end) && stay.EndDate.After(start) {
+				occupied[stay.RoomID] = true
+			}
+		}
+	}
+	return occupied, nil
+}
+
+// SaveReservation creates or updates a reservation on behalf of actorID.
+// When the reservation holds its room, the room row is locked and
+// availability is checked in the same transaction as the write so that
+// concurrent bookings cannot overlap. A stay cannot be booked into or moved
+// to a room that is out of service, though one already in it keeps it. The
+// party must fit in the room type. An update that changes the room type,
+// dates or party is priced again under its rate plan, and the folio of a
+// checked-in stay is adjusted to the new price; moving to another room of
+// the same type keeps the price.
+// The room of a stay split by MoveReservation cannot change here, but its
+// dates can, which resizes its segments. An update must carry the Version
+// of the stored reservation or fails with repository.ErrVersionConflict.
+// Every change is added to the reservation history.
+func SaveReservation(store repository.Store, reservation *models.Reservation, actorID uint) error {
+	return store.Transaction(func(tx repository.Store) error {
+		var before *models.Reservation
+		if reservation.ID != 0 {
+			var err error
+			before, err = tx.Reservations().FindByIDForUpdate(reservation.ID)
+			if err != nil {
+				return err
+			}
+			if reservation.Version != before.Version {
+				return repository.ErrVersionConflict
+			}
+		} else {
+			// DeleteUser takes the same lock, so the guest cannot be
+			// deleted from under a new booking.
+			_, err := tx.Users().FindByIDForUpdate(reservation.UserID)
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrUserNotFound
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		// Segments only come from MoveReservation.
+		roomChanged := before == nil || before.RoomID != reservation.RoomID
+		reservation.Segments = nil
+		if before != nil && len(before.Segments) > 0 {
+			if roomChanged {
+				return ErrStaySplit
+			}
+			segments := resizeSegments(before.Segments, reservation.StartDate, reservation.EndDate)
+			reservation.RoomID = segments[len(segments)-1].RoomID
+			if len(segments) > 1 {
+				reservation.Segments = segments
+			}
+		}
+
+		if IsBlocking(reservation.Status) {
+			for _, stay := range roomStays(reservation) {
+				room, err := lockRoom(tx, stay.RoomID)
+				if err != nil {
+					return err
+				}
+				if roomChanged && room.Status == models.RoomOutOfService {
+					return ErrRoomOutOfService
+				}
+				if err := CheckAvailability(tx, stay.RoomID, stay.StartDate, stay.EndDate, reservation.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if roomChanged {
+			room, err := tx.Rooms().FindByID(reservation.RoomID)
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrRoomNotFound
+			}
+			if err != nil {
+				return err
+			}
+			reservation.RoomTypeID = room.RoomTypeID
+		} else {
+			reservation.RoomTypeID = before.RoomTypeID
+		}
+
+		if before == nil || stayChanged(before, reservation) {
+			roomType, err := FindRoomType(tx, reservation.RoomTypeID)
+			if err != nil {
+				return err
+			}
+			if err := ValidateGuests(roomType, reservation); err != nil {
+				return err
+			}
+		}
+
+		return storeReservation(tx, before, reservation, actorID)
+	})
+}
+
+// storeReservation writes a reservation that SaveReservation or the room
+// optimizer checked, given as it was before the change or nil for a new
+// one, and adds the change to the reservation history. An update keeps the
+// stored price unless the stay itself changed.
+func storeReservation(tx repository.Store, before, reservation *models.Reservation, actorID uint) error {
+	if before == nil {
+		attributeNights(reservation)
+		if err := tx.Reservations().Create(reservation); err != nil {
+			return err
+		}
+		return recordChanges(tx, before, reservation, actorID)
+	}
+
+	// The stored price only changes when the stay itself does; anything
+	// the caller put in the pricing fields is ignored.
+	if stayChanged(before, reservation) {
+		if err := repriceReservation(tx, reservation); err != nil {
+			return err
+		}
+		attributeNights(reservation)
+		if err := tx.Reservations().ReplacePricing(reservation.ID, reservation.Nights, reservation.Taxes); err != nil {
+			return err
+		}
+		if err := adjustRoomCharge(tx, before, reservation, actorID); err != nil {
+			return err
+		}
+	} else {
+		reservation.RatePlanID = before.RatePlanID
+		reservation.Currency = before.Currency
+		reservation.TotalAmount = before.TotalAmount
+		reservation.TaxAmount = before.TaxAmount
+		reservation.Nights = append([]models.ReservationNight(nil), before.Nights...)
+		reservation.Taxes = before.Taxes
+		if attributeNights(reservation) {
+			if err := tx.Reservations().ReplacePricing(reservation.ID, reservation.Nights, reservation.Taxes); err != nil {
+				return err
+			}
+		}
+	}
+	if len(before.Segments) > 0 && (!before.StartDate.Equal(reservation.StartDate) || !before.EndDate.Equal(reservation.EndDate)) {
+		if err := tx.Reservations().ReplaceSegments(reservation.ID, reservation.Segments); err != nil {
+			return err
+		}
+	}
+	if err := tx.Reservations().Save(reservation); err != nil {
+		return err
+	}
+	return recordChanges(tx, before, reservation, actorID)
+}
+
+// AvailabilityQuery filters a search for rooms free over a whole stay.
+// Zero values of Type, Guests and MaxPrice disable the matching filter.
+// Type is the name of a room type. Guests counts adults; Children are added
+// to them for the occupancy check and both are priced. MaxPrice is a nightly
+// price in minor units of the base currency.
+type AvailabilityQuery struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Type      string
+	Guests    int
+	Children  int
+	MaxPrice  int64
+}
+
+// AvailableRoom is a free room of an AvailableRoomType.
+type AvailableRoom struct {
+	ID     uint   `json:"id"`
+	Number string `json:"number"`
+}
+
+// AvailableRoomType is a room type with free rooms, the price of the
+// dearest night of the stay and the price of the whole stay, in minor units
+// of Currency. Any of Rooms can be booked at that price.
+type AvailableRoomType struct {
+	TypeID       uint            `json:"type_id"`
+	Type         string          `json:"type"`
+	Description  string          `json:"description"`
+	MaxOccupancy int             `json:"max_occupancy"`
+	Beds         string          `json:"beds"`
+	Amenities    []string        `json:"amenities"`
+	Photos       []string        `json:"photos"`
+	Currency     string          `json:"currency"`
+	Price        int64           `json:"price"`
+	TotalPrice   int64           `json:"total_price"`
+	Available    int             `json:"available"`
+	Rooms        []AvailableRoom `json:"rooms"`
+}
+
+// Nights returns the number of nights between the calendar days of start and
+// end, counting a same-day stay as one night.
+func Nights(start, end time.Time) int {
+	sy, sm, sd := start.UTC().Date()
+	ey, em, ed := end.UTC().Date()
+	days := int(time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC).Sub(time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	if days < 1 {
+		return 1
+	}
+	return days
+}
+
+// SearchAvailability returns the room types matching the query that have
+// rooms in service and free for the whole stay, ordered by name. The total
+// price is quoted under the default rate plan, taxes included. MaxPrice
+// keeps the types whose dearest night, before taxes, costs no more.
+func SearchAvailability(store repository.Store, query AvailabilityQuery) ([]AvailableRoomType, error) {
+	if !query.EndDate.After(query.StartDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	plan, err := FindRatePlan(store, DefaultRatePlan)
+	if err != nil {
+		return nil, err
+	}
+	taxes := make(map[string][]models.Tax)
+	conv := newConverter(store)
+
+	roomTypes, err := store.RoomTypes().FindAll()
+	if err != nil {
+		return nil, err
+	}
+	occupied, err := OccupiedRooms(store, query.StartDate, query.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]AvailableRoomType, 0, len(roomTypes))
+	for _, roomType := range roomTypes {
+		if query.Type != "" && roomType.Name != query.Type {
+			continue
+		}
+		if query.Guests+query.Children > roomType.MaxOccupancy {
+			continue
+		}
+
+		rooms, err := store.Rooms().FindByRoomType(roomType.ID)
+		if err != nil {
+			return nil, err
+		}
+		rooms = freeRooms(rooms, occupied)
+		if len(rooms) == 0 {
+			continue
+		}
+
+		adults := max(query.Guests, 1)
+		nights, err := PriceStay(store, &roomType, plan, query.StartDate, query.EndDate, adults, query.Children)
+		if err != nil {
+			return nil, err
+		}
+		var highest int64
+		for _, night := range nights {
+			highest = max(highest, night.Amount)
+		}
+		if query.MaxPrice > 0 {
+			price, err := conv.convert(highest, roomType.Currency, BaseCurrency(), time.Now())
+			if err != nil {
+				return nil, err
+			}
+			if price > query.MaxPrice {
+				continue
+			}
+		}
+		if _, ok := taxes[roomType.Currency]; !ok {
+			taxes[roomType.Currency], err = ActiveTaxes(store, roomType.Currency)
+			if err != nil {
+				return nil, err
+			}
+		}
+		total, _ := stayTotal(taxes[roomType.Currency], nights, adults+query.Children)
+
+		available := AvailableRoomType{
+			TypeID:       roomType.ID,
+			Type:         roomType.Name,
+			Description:  roomType.Description,
+			MaxOccupancy: roomType.MaxOccupancy,
+			Beds:         roomType.Beds,
+			Amenities:    roomType.Amenities,
+			Photos:       roomType.Photos,
+			Currency:     roomType.Currency,
+			Price:        highest,
+			TotalPrice:   total,
+			Available:    len(rooms),
+			Rooms:        make([]AvailableRoom, 0, len(rooms)),
+		}
+		for _, room := range rooms {
+			available.Rooms = append(available.Rooms, AvailableRoom{ID: room.ID, Number: room.Number})
+		}
+		result = append(result, available)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+	return result, nil
+}
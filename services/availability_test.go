@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"testing"
+	"time"
+)
+
+// newTestStore returns a memory store holding a guest with ID 1 and rooms
+// 101 and 102 of room type 1, sold at 100.00 USD a night.
+func newTestStore(t *testing.T) repository.Store {
+	t.Helper()
+	store := repository.NewMemoryStore()
+	roomType := &models.RoomType{Name: "double", BasePrice: 10000, Currency: "USD", MaxOccupancy: 2, BaseOccupancy: 2}
+	if err := store.RoomTypes().Create(roomType); err != nil {
+		t.Fatal(err)
+	}
+	for _, number := range []string{"101", "102"} {
+		if err := store.Rooms().Create(&models.Room{Number: number, RoomTypeID: roomType.ID, Status: "available"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Users().Create(&models.User{Username: "guest", Email: "guest@example.com", Role: "customer"}); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// book prices and saves a pending stay of the guest in roomID under the
+// standard rate plan.
+func book(t *testing.T, store repository.Store, roomID uint, start, end string) *models.Reservation {
+	t.Helper()
+	return bookStay(t, store, roomID, day(t, start), day(t, end))
+}
+
+// bookStay is book for dates that are not known in advance.
+func bookStay(t *testing.T, store repository.Store, roomID uint, start, end time.Time) *models.Reservation {
+	t.Helper()
+	reservation := &models.Reservation{UserID: 1, RoomID: roomID, StartDate: start, EndDate: end, Status: "pending", Adults: 1}
+	plan, err := FindRatePlan(store, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := PriceReservation(store, reservation, plan); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveReservation(store, reservation, 1); err != nil {
+		t.Fatal(err)
+	}
+	return reservation
+}
+
+func TestSaveReservationPricing(t *testing.T) {
+	store := newTestStore(t)
+	reservation := book(t, store, 1, "2026-01-05", "2026-01-07")
+	if reservation.TotalAmount != 20000 || reservation.Currency != BaseCurrency() {
+		t.Fatalf("booked at %d %s, want 20000 %s", reservation.TotalAmount, reservation.Currency, BaseCurrency())
+	}
+
+	tests := []struct {
+		name   string
+		change func(*models.Reservation)
+		want   int64
+		nights int
+	}{
+		{
+			name:   "price fields set by the caller are ignored",
+			change: func(r *models.Reservation) { r.TotalAmount = 1 },
+			want:   20000,
+			nights: 2,
+		},
+		{
+			name:   "longer stay is priced again",
+			change: func(r *models.Reservation) { r.EndDate = day(t, "2026-01-08") },
+			want:   30000,
+			nights: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservation, err := store.Reservations().FindByID(reservation.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.change(reservation)
+			if err := SaveReservation(store, reservation, 1); err != nil {
+				t.Fatal(err)
+			}
+			stored, err := store.Reservations().FindByID(reservation.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.TotalAmount != tt.want || len(stored.Nights) != tt.nights {
+				t.Errorf("got %d over %d nights, want %d over %d", stored.TotalAmount, len(stored.Nights), tt.want, tt.nights)
+			}
+		})
+	}
+}
+
+// TestSaveReservationOutOfService puts room 102 out of service: a stay
+// cannot be moved into it, while the stay already in it can still change.
+func TestSaveReservationOutOfService(t *testing.T) {
+	store := newTestStore(t)
+	moving := book(t, store, 1, "2026-01-05", "2026-01-07")
+	staying := book(t, store, 2, "2026-01-10", "2026-01-12")
+	room, err := store.Rooms().FindByID(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	room.Status = models.RoomOutOfService
+	if err := store.Rooms().Save(room); err != nil {
+		t.Fatal(err)
+	}
+
+	moving.RoomID = 2
+	if err := SaveReservation(store, moving, 1); !errors.Is(err, ErrRoomOutOfService) {
+		t.Errorf("moving into the room: got %v, want %v", err, ErrRoomOutOfService)
+	}
+	staying.EndDate = day(t, "2026-01-13")
+	if err := SaveReservation(store, staying, 1); err != nil {
+		t.Errorf("changing the stay in the room: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"time"
+)
+
+// cancellationPolicies are the penalties a rate plan can charge once its
+// free cancellation window has passed: nothing, the first night or the whole
+// stay.
+var cancellationPolicies = map[string]bool{
+	"flexible":       true,
+	"first-night":    true,
+	"non-refundable": true,
+}
+
+// NormalizePaymentTerms defaults the cancellation policy of plan to flexible
+// and reports whether its deposit and cancellation terms are valid.
+func NormalizePaymentTerms(plan *models.RatePlan) bool {
+	if plan.CancellationPolicy == "" {
+		plan.CancellationPolicy = "flexible"
+	}
+	return cancellationPolicies[plan.CancellationPolicy] &&
+		plan.DepositPercent >= 0 && plan.DepositPercent <= 100 &&
+		plan.FreeCancellationDays >= 0
+}
+
+// CancellationQuote is what cancelling a reservation costs at a given time.
+// Amounts are in minor units of Currency.
+type CancellationQuote struct {
+	ReservationID uint   `json:"reservation_id"`
+	Policy        string `json:"policy"`
+	// FreeUntil is the moment cancelling stops being free, when the rate
+	// plan has a free cancellation window.
+	FreeUntil *time.Time `json:"free_until,omitempty"`
+	Penalty   int64      `json:"penalty"`
+	Paid      int64      `json:"paid"`
+	Refund    int64      `json:"refund"`
+	Currency  string     `json:"currency"`
+}
+
+// freeUntil returns the end of the free cancellation window of a stay:
+// cancelling is free up to freeDays whole days before the arrival day.
+func freeUntil(plan *models.RatePlan, reservation *models.Reservation) *time.Time {
+	if plan.FreeCancellationDays <= 0 {
+		return nil
+	}
+	until := dayOf(reservation.StartDate).AddDate(0, 0, 1-plan.FreeCancellationDays)
+	return &until
+}
+
+// CancellationPenalty is what a guest forfeits by cancelling reservation at
+// now under the policy of plan.
+func CancellationPenalty(plan *models.RatePlan, reservation *models.Reservation, now time.Time) int64 {
+	if until := freeUntil(plan, reservation); until != nil && now.Before(*until) {
+		return 0
+	}
+	switch plan.CancellationPolicy {
+	case "first-night":
+		if len(reservation.Nights) > 0 {
+			return reservation.Nights[0].Amount
+		}
+		return reservation.TotalAmount / int64(Nights(reservation.StartDate, reservation.EndDate))
+	case "non-refundable":
+		return reservation.TotalAmount
+	}
+	return 0
+}
+
+// quoteCancellation prices the cancellation of reservation at now under the
+// policy of the plan it was booked with. The refund is the credit the folio
+// would keep after the penalty, up to what went through the payment gateway.
+func quoteCancellation(store repository.Store, reservation *models.Reservation, now time.Time) (*CancellationQuote, error) {
+	plan, err := bookedRatePlan(store, reservation)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := store.Payments().FindByReservation(reservation.ID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := folioBalance(store, reservation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, paid := refundablePayments(payments)
+	penalty := CancellationPenalty(plan, reservation, now)
+	return &CancellationQuote{
+		ReservationID: reservation.ID,
+		Policy:        plan.CancellationPolicy,
+		FreeUntil:     freeUntil(plan, reservation),
+		Penalty:       penalty,
+		Paid:          paid,
+		Refund:        max(0, min(-(balance+penalty), paid)),
+		Currency:      reservation.Currency,
+	}, nil
+}
+
+// PreviewCancellation tells what cancelling a reservation now would cost
+// without changing anything.
+func PreviewCancellation(store repository.Store, id uint) (*CancellationQuote, error) {
+	reservation, err := store.Reservations().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTransition(reservation.Status, "cancelled"); err != nil {
+		return nil, err
+	}
+	return quoteCancellation(store, reservation, time.Now())
+}
+
+// CancelReservation cancels a reservation and posts its cancellation penalty
+// to the folio in one transaction. Refunds are left to RefundCancelled,
+// which needs the payment gateway.
+func CancelReservation(store repository.Store, id uint, actorID uint) (*models.Reservation, *CancellationQuote, error) {
+	var reservation *models.Reservation
+	var quote *CancellationQuote
+	err := store.Transaction(func(tx repository.Store) error {
+		var err error
+		reservation, err = tx.Reservations().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if err := ValidateTransition(reservation.Status, "cancelled"); err != nil {
+			return err
+		}
+
+		quote, err = quoteCancellation(tx, reservation, time.Now())
+		if err != nil {
+			return err
+		}
+		if quote.Penalty > 0 {
+			penalty := &models.FolioLine{
+				Type:        "debit",
+				Category:    "cancellation",
+				Description: "Cancellation penalty (" + quote.Policy + ")",
+				Amount:      quote.Penalty,
+				PostedBy:    actorID,
+			}
+			if err := postLine(tx, reservation, penalty); err != nil {
+				return err
+			}
+		}
+
+		reservation.Status = "cancelled"
+		StampStatus(reservation, actorID)
+		return SaveReservation(tx, reservation, actorID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return reservation, quote, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"testing"
+	"time"
+)
+
+func TestCancellationPenalty(t *testing.T) {
+	// Three nights from January 10 at 100, 120 and 130.
+	reservation := &models.Reservation{
+		StartDate:   time.Date(2026, 1, 10, 14, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2026, 1, 13, 11, 0, 0, 0, time.UTC),
+		TotalAmount: 35000,
+		Nights:      []models.ReservationNight{{Amount: 10000}, {Amount: 12000}, {Amount: 13000}},
+	}
+	at := func(value string) time.Time {
+		now, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return now
+	}
+
+	tests := []struct {
+		name     string
+		policy   string
+		freeDays int
+		now      time.Time
+		want     int64
+	}{
+		{name: "flexible", policy: "flexible", now: at("2026-01-10T12:00:00Z"), want: 0},
+		{name: "first night", policy: "first-night", now: at("2026-01-01T00:00:00Z"), want: 10000},
+		{name: "non-refundable", policy: "non-refundable", now: at("2026-01-01T00:00:00Z"), want: 35000},
+		{name: "inside the free window", policy: "non-refundable", freeDays: 2, now: at("2026-01-08T23:59:59Z"), want: 0},
+		{name: "window closes two days before arrival", policy: "non-refundable", freeDays: 2, now: at("2026-01-09T00:00:00Z"), want: 35000},
+		{name: "window of one day closes on arrival", policy: "first-night", freeDays: 1, now: at("2026-01-10T00:00:00Z"), want: 10000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &models.RatePlan{CancellationPolicy: tt.policy, FreeCancellationDays: tt.freeDays}
+			if got := CancellationPenalty(plan, reservation, tt.now); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCancellationPenaltyFirstNightWithoutBreakdown(t *testing.T) {
+	reservation := &models.Reservation{
+		StartDate:   time.Date(2026, 1, 10, 14, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC),
+		TotalAmount: 40000,
+	}
+	plan := &models.RatePlan{CancellationPolicy: "first-night"}
+	if got := CancellationPenalty(plan, reservation, reservation.StartDate); got != 10000 {
+		t.Errorf("got %d, want 10000", got)
+	}
+}
+
+func TestCancelReservation(t *testing.T) {
+	store := newTestStore(t)
+	plan, err := FindRatePlan(store, "non-refundable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reservation := book(t, store, 1, "2026-01-05", "2026-01-07")
+	reservation.RatePlanID = plan.ID
+	if err := store.Reservations().Save(reservation); err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := PreviewCancellation(store, reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Penalty != 20000 || preview.Refund != 0 {
+		t.Errorf("got preview %+v, want a 20000 penalty and no refund", preview)
+	}
+	if folio, err := GetFolio(store, reservation.ID); err != nil || len(folio.Lines) != 0 {
+		t.Fatalf("preview posted %+v, %v", folio, err)
+	}
+
+	reservation, quote, err := CancelReservation(store, reservation.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reservation.Status != "cancelled" || quote.Penalty != 20000 {
+		t.Errorf("got %s with penalty %d, want cancelled with 20000", reservation.Status, quote.Penalty)
+	}
+	folio, err := GetFolio(store, reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folio.Lines) != 1 || folio.Lines[0].Category != "cancellation" || folio.Balance != 20000 {
+		t.Errorf("got folio %+v, want the 20000 penalty", folio)
+	}
+
+	if _, _, err := CancelReservation(store, reservation.ID, 1); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("cancelling again: got %v, want %v", err, ErrInvalidTransition)
+	}
+}
+
+// TestPreviewCancellationWithoutPlan quotes a stay booked before rate plans
+// existed under the policy of the default plan.
+func TestPreviewCancellationWithoutPlan(t *testing.T) {
+	store := newTestStore(t)
+	reservation := book(t, store, 1, "2026-01-05", "2026-01-07")
+	reservation.RatePlanID = 0
+	if err := store.Reservations().Save(reservation); err != nil {
+		t.Fatal(err)
+	}
+	standard, err := FindRatePlan(store, DefaultRatePlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := PreviewCancellation(store, reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Policy != standard.CancellationPolicy {
+		t.Errorf("got policy %q, want the default plan's %q", preview.Policy, standard.CancellationPolicy)
+	}
+}
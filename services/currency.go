@@ -0,0 +1,212 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseCurrency is the base currency of a property that does not set
+// BASE_CURRENCY.
+const DefaultBaseCurrency = "USD"
+
+var (
+	ErrInvalidCurrency     = errors.New("invalid currency code")
+	ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+	ErrNoExchangeRate      = errors.New("no exchange rate")
+)
+
+// BaseCurrency is the currency the property reports in. Rate overrides,
+// plan supplements and fixed taxes are configured in it, and exchange
+// rates are quoted against it.
+func BaseCurrency() string {
+	if currency := strings.ToUpper(os.Getenv("BASE_CURRENCY")); ValidCurrency(currency) {
+		return currency
+	}
+	return DefaultBaseCurrency
+}
+
+// ValidCurrency reports whether code looks like an ISO 4217 code.
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeCurrency upper-cases a currency code, defaulting an empty one
+// to the base currency.
+func NormalizeCurrency(code string) (string, error) {
+	if code == "" {
+		return BaseCurrency(), nil
+	}
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !ValidCurrency(code) {
+		return "", ErrInvalidCurrency
+	}
+	return code, nil
+}
+
+// currencyExponents lists the currencies whose minor unit is not a
+// hundredth of the major unit.
+var currencyExponents = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"UGX": 0,
+	"VND": 0,
+}
+
+// minorUnits is the number of minor units in one major unit of currency.
+func minorUnits(currency string) float64 {
+	exponent, ok := currencyExponents[currency]
+	if !ok {
+		exponent = 2
+	}
+	return math.Pow10(exponent)
+}
+
+// ToMinorUnits converts a price in major units of currency to minor units,
+// the unit every stored amount uses.
+func ToMinorUnits(price float64, currency string) int64 {
+	return int64(math.Round(price * minorUnits(currency)))
+}
+
+// ToMajorUnits converts an amount in minor units of currency back to major
+// units.
+func ToMajorUnits(amount int64, currency string) float64 {
+	return float64(amount) / minorUnits(currency)
+}
+
+// converter converts amounts between currencies with the rates stored on a
+// given day, remembering the rates it has looked up.
+type converter struct {
+	store repository.Store
+	rates map[string]float64
+}
+
+func newConverter(store repository.Store) *converter {
+	return &converter{store: store, rates: make(map[string]float64)}
+}
+
+// rate is the value of one unit of currency in the base currency on date.
+func (c *converter) rate(currency string, date time.Time) (float64, error) {
+	if currency == BaseCurrency() {
+		return 1, nil
+	}
+	day := dayOf(date)
+	key := currency + day.Format("2006-01-02")
+	if rate, ok := c.rates[key]; ok {
+		return rate, nil
+	}
+	rate, err := c.store.ExchangeRates().FindLatest(currency, day)
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, fmt.Errorf("%w for %s on %s", ErrNoExchangeRate, currency, day.Format("2006-01-02"))
+	}
+	if err != nil {
+		return 0, err
+	}
+	c.rates[key] = rate.Rate
+	return rate.Rate, nil
+}
+
+// convert converts amount in minor units of from into minor units of to at
+// the rates of date.
+func (c *converter) convert(amount int64, from, to string, date time.Time) (int64, error) {
+	if from == to || amount == 0 {
+		return amount, nil
+	}
+	fromRate, err := c.rate(from, date)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := c.rate(to, date)
+	if err != nil {
+		return 0, err
+	}
+	base := ToMajorUnits(amount, from) * fromRate
+	return ToMinorUnits(base/toRate, to), nil
+}
+
+// Convert converts amount in minor units of from into minor units of to at
+// the exchange rates of date.
+func Convert(store repository.Store, amount int64, from, to string, date time.Time) (int64, error) {
+	return newConverter(store).convert(amount, from, to, date)
+}
+
+// ValidateExchangeRate normalizes a rate to an upper case currency and a
+// UTC day, and checks that it is quoted against the base currency.
+func ValidateExchangeRate(rate *models.ExchangeRate) error {
+	rate.Currency = strings.ToUpper(strings.TrimSpace(rate.Currency))
+	if !ValidCurrency(rate.Currency) || rate.Currency == BaseCurrency() {
+		return ErrInvalidCurrency
+	}
+	if rate.Date.IsZero() || rate.Rate <= 0 || math.IsInf(rate.Rate, 0) || math.IsNaN(rate.Rate) {
+		return ErrInvalidExchangeRate
+	}
+	rate.Date = dayOf(rate.Date)
+	return nil
+}
+
+// ParseExchangeRatesCSV reads rates from CSV rows of currency, date
+// (YYYY-MM-DD) and rate. A header row starting with "currency" is skipped.
+func ParseExchangeRatesCSV(r io.Reader) ([]models.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExchangeRate, err)
+	}
+
+	rates := make([]models.ExchangeRate, 0, len(records))
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "currency") {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", record[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: bad date %q", ErrInvalidExchangeRate, i+1, record[1])
+		}
+		value, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: bad rate %q", ErrInvalidExchangeRate, i+1, record[2])
+		}
+		rates = append(rates, models.ExchangeRate{Currency: record[0], Date: date, Rate: value})
+	}
+	return rates, nil
+}
+
+// SaveExchangeRates validates and stores uploaded rates, replacing those
+// already stored for the same currency and day.
+func SaveExchangeRates(store repository.Store, rates []models.ExchangeRate) error {
+	now := time.Now()
+	for i := range rates {
+		if err := ValidateExchangeRate(&rates[i]); err != nil {
+			return fmt.Errorf("%w: entry %d", err, i+1)
+		}
+		rates[i].ID = 0
+		rates[i].CreatedAt = now
+		rates[i].UpdatedAt = now
+	}
+	return store.ExchangeRates().Upsert(rates)
+}
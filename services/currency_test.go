@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"strings"
+	"testing"
+)
+
+// saveRates stores exchange rates to the base currency, given as currency,
+// day and rate.
+func saveRates(t *testing.T, store repository.Store, rates ...models.ExchangeRate) {
+	t.Helper()
+	if err := SaveExchangeRates(store, rates); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		currency string
+		price    float64
+		want     int64
+	}{
+		{currency: "USD", price: 100.5, want: 10050},
+		{currency: "USD", price: 0.125, want: 13},
+		{currency: "JPY", price: 15000, want: 15000},
+		{currency: "KWD", price: 12.345, want: 12345},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			got := ToMinorUnits(tt.price, tt.currency)
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+			if back := ToMajorUnits(got, tt.currency); ToMinorUnits(back, tt.currency) != got {
+				t.Errorf("%d converts back to %v", got, back)
+			}
+		})
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+		err  error
+	}{
+		{code: "", want: BaseCurrency()},
+		{code: " eur ", want: "EUR"},
+		{code: "EURO", err: ErrInvalidCurrency},
+		{code: "E1R", err: ErrInvalidCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := NormalizeCurrency(tt.code)
+			if !errors.Is(err, tt.err) || got != tt.want {
+				t.Errorf("got %q, %v, want %q, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	store := repository.NewMemoryStore()
+	saveRates(t, store,
+		models.ExchangeRate{Currency: "EUR", Date: day(t, "2026-01-01"), Rate: 1.1},
+		models.ExchangeRate{Currency: "EUR", Date: day(t, "2026-01-10"), Rate: 1.2},
+		models.ExchangeRate{Currency: "JPY", Date: day(t, "2026-01-01"), Rate: 0.0067},
+	)
+
+	tests := []struct {
+		name     string
+		amount   int64
+		from, to string
+		date     string
+		want     int64
+		err      error
+	}{
+		{name: "same currency", amount: 12345, from: "EUR", to: "EUR", date: "2025-01-01", want: 12345},
+		{name: "to the base currency", amount: 10000, from: "EUR", to: "USD", date: "2026-01-05", want: 11000},
+		{name: "latest rate on or before the day", amount: 10000, from: "EUR", to: "USD", date: "2026-01-15", want: 12000},
+		{name: "from the base currency", amount: 11000, from: "USD", to: "EUR", date: "2026-01-05", want: 10000},
+		{name: "rounded to the minor unit", amount: 333, from: "EUR", to: "USD", date: "2026-01-05", want: 366},
+		{name: "between two foreign currencies", amount: 10000, from: "EUR", to: "JPY", date: "2026-01-05", want: 16418},
+		{name: "before the first rate", amount: 10000, from: "EUR", to: "USD", date: "2025-12-31", err: ErrNoExchangeRate},
+		{name: "unknown currency", amount: 10000, from: "GBP", to: "USD", date: "2026-01-05", err: ErrNoExchangeRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(store, tt.amount, tt.from, tt.to, day(t, tt.date))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveExchangeRates(t *testing.T) {
+	store := repository.NewMemoryStore()
+	saveRates(t, store, models.ExchangeRate{Currency: "eur", Date: day(t, "2026-01-01"), Rate: 1.1})
+	// A second upload for the same day replaces the first.
+	saveRates(t, store, models.ExchangeRate{Currency: "EUR", Date: day(t, "2026-01-01"), Rate: 1.2})
+
+	rates, err := store.ExchangeRates().FindAll("EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 1 || rates[0].Rate != 1.2 {
+		t.Errorf("got %+v, want one EUR rate of 1.2", rates)
+	}
+
+	for _, rate := range []models.ExchangeRate{
+		{Currency: BaseCurrency(), Date: day(t, "2026-01-01"), Rate: 1},
+		{Currency: "EUR", Rate: 1.1},
+		{Currency: "EUR", Date: day(t, "2026-01-01"), Rate: -1},
+	} {
+		if err := SaveExchangeRates(store, []models.ExchangeRate{rate}); err == nil {
+			t.Errorf("saved %+v", rate)
+		}
+	}
+}
+
+func TestParseExchangeRatesCSV(t *testing.T) {
+	rates, err := ParseExchangeRatesCSV(strings.NewReader("currency,date,rate\nEUR,2026-01-01,1.1\nGBP, 2026-01-01, 1.25\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 2 || rates[1].Currency != "GBP" || rates[1].Rate != 1.25 || !rates[1].Date.Equal(day(t, "2026-01-01")) {
+		t.Errorf("got %+v", rates)
+	}
+
+	for _, input := range []string{"EUR,01/01/2026,1.1\n", "EUR,2026-01-01,one\n", "EUR,2026-01-01\n"} {
+		if _, err := ParseExchangeRatesCSV(strings.NewReader(input)); !errors.Is(err, ErrInvalidExchangeRate) {
+			t.Errorf("%q: got %v, want %v", input, err, ErrInvalidExchangeRate)
+		}
+	}
+}
+
+// TestPriceStayInRoomCurrency prices a room type sold in euros. Overrides and
+// supplements are in the base currency and converted at today's rate.
+func TestPriceStayInRoomCurrency(t *testing.T) {
+	store := repository.NewMemoryStore()
+	saveRates(t, store, models.ExchangeRate{Currency: "EUR", Date: day(t, "2000-01-01"), Rate: 1.25})
+	roomType := &models.RoomType{ID: 1, Name: "suite", BasePrice: 16000, Currency: "EUR"}
+	if err := store.Rates().CreateOverride(&models.RateOverride{RoomTypeID: 1, Weekdays: "sat", Amount: 25000}); err != nil {
+		t.Fatal(err)
+	}
+	plan, err := FindRatePlan(store, "breakfast-included")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Friday, then a Saturday at the override.
+	nights, err := PriceStay(store, roomType, plan, day(t, "2026-01-02"), day(t, "2026-01-04"), 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := amounts(nights); len(got) != 2 || got[0] != 17200 || got[1] != 21200 {
+		t.Errorf("got %v, want [17200 21200]", got)
+	}
+}
+
+// TestRevenueInBaseCurrency checks that revenue booked in another currency
+// is reported in the base currency at the rate of the booking day.
+func TestRevenueInBaseCurrency(t *testing.T) {
+	store := newTestStore(t)
+	saveRates(t, store, models.ExchangeRate{Currency: "EUR", Date: day(t, "2000-01-01"), Rate: 1.1})
+	roomType := &models.RoomType{Name: "euro double", BasePrice: 9000, Currency: "EUR", MaxOccupancy: 2, BaseOccupancy: 2}
+	if err := store.RoomTypes().Create(roomType); err != nil {
+		t.Fatal(err)
+	}
+	room := &models.Room{Number: "301", RoomTypeID: roomType.ID, Status: "available"}
+	if err := store.Rooms().Create(room); err != nil {
+		t.Fatal(err)
+	}
+	for _, roomID := range []uint{1, room.ID} {
+		reservation := book(t, store, roomID, "2026-01-05", "2026-01-07")
+		reservation.Status = "confirmed"
+		if err := SaveReservation(store, reservation, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	total, err := TotalRevenue(store, day(t, "2026-01-01"), day(t, "2026-01-31"), []string{"confirmed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.Gross != 20000+19800 {
+		t.Errorf("got %d, want 39800", total.Gross)
+	}
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"os"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrRoomInUse        = errors.New("room has current or upcoming reservations")
+	ErrUserHasBookings  = errors.New("user has current or upcoming reservations")
+	ErrRoomDeleted      = errors.New("the room of the reservation was deleted")
+	ErrInvalidRetention = errors.New("retention must be a whole number of days")
+)
+
+// DefaultDeletedRetention is how long deleted users, rooms and reservations
+// can be restored before PurgeDeleted removes them.
+const DefaultDeletedRetention = 90 * 24 * time.Hour
+
+// ConfiguredDeletedRetention returns the retention set in days by the
+// DELETED_RETENTION_DAYS environment variable, DefaultDeletedRetention when
+// it is not set.
+func ConfiguredDeletedRetention() (time.Duration, error) {
+	value := os.Getenv("DELETED_RETENTION_DAYS")
+	if value == "" {
+		return DefaultDeletedRetention, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, ErrInvalidRetention
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// DeleteRoom deletes a room at version that no current or upcoming stay is
+// booked in. The room row is locked, so no booking of it can slip in before
+// the delete.
+func DeleteRoom(store repository.Store, id, version uint) error {
+	return store.Transaction(func(tx repository.Store) error {
+		if _, err := tx.Rooms().FindByIDForUpdate(id); err != nil {
+			return err
+		}
+		now := time.Now()
+		err := CheckAvailability(tx, id, now, now.AddDate(100, 0, 0), 0)
+		if errors.Is(err, ErrRoomUnavailable) {
+			return ErrRoomInUse
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Rooms().Delete(id, version)
+	})
+}
+
+// DeleteUser deletes a user at version who has no pending, confirmed or
+// checked-in reservation. The user row is locked, as SaveReservation locks
+// it for a new booking, so none can slip in before the delete.
+func DeleteUser(store repository.Store, id, version uint) error {
+	return store.Transaction(func(tx repository.Store) error {
+		if _, err := tx.Users().FindByIDForUpdate(id); err != nil {
+			return err
+		}
+		reservations, err := tx.Reservations().FindByUser(id, BlockingStatuses)
+		if err != nil {
+			return err
+		}
+		if len(reservations) > 0 {
+			return ErrUserHasBookings
+		}
+		return tx.Users().Delete(id, version)
+	})
+}
+
+// RestoreRoom brings back a deleted room, provided its room type still
+// exists.
+func RestoreRoom(store repository.Store, id uint) (*models.Room, error) {
+	var room *models.Room
+	err := store.Transaction(func(tx repository.Store) error {
+		if err := tx.Rooms().Restore(id); err != nil {
+			return err
+		}
+		var err error
+		room, err = tx.Rooms().FindByID(id)
+		if err != nil {
+			return err
+		}
+		_, err = FindRoomType(tx, room.RoomTypeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// RestoreReservation brings back a deleted reservation. One that holds its
+// rooms needs them to exist and to still be free, as the nights may have
+// been sold again in the meantime.
+func RestoreReservation(store repository.Store, id uint) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := store.Transaction(func(tx repository.Store) error {
+		if err := tx.Reservations().Restore(id); err != nil {
+			return err
+		}
+		var err error
+		reservation, err = tx.Reservations().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if !IsBlocking(reservation.Status) {
+			return nil
+		}
+		for _, stay := range roomStays(reservation) {
+			_, err := tx.Rooms().FindByIDForUpdate(stay.RoomID)
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrRoomDeleted
+			}
+			if err != nil {
+				return err
+			}
+			if err := CheckAvailability(tx, stay.RoomID, stay.StartDate, stay.EndDate, reservation.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// PurgeResult counts what PurgeDeleted removed.
+type PurgeResult struct {
+	Users        int
+	Rooms        int
+	Reservations int
+}
+
+// PurgeDeleted removes the users, rooms and reservations deleted more than
+// retention ago for good. Reservations with a folio, payments or an invoice
+// are financial records and are kept. Rooms and users are kept while any
+// reservation, deleted or not, refers to them, so they go in a later run
+// once their reservations have.
+func PurgeDeleted(store repository.Store, retention time.Duration) (PurgeResult, error) {
+	var result PurgeResult
+	cutoff := time.Now().Add(-retention)
+	err := store.Transaction(func(tx repository.Store) error {
+		reservations, err := tx.Reservations().FindDeletedBefore(cutoff)
+		if err != nil {
+			return err
+		}
+		for _, reservation := range reservations {
+			kept, err := hasFinancialRecords(tx, reservation.ID)
+			if err != nil {
+				return err
+			}
+			if kept {
+				continue
+			}
+			if err := tx.Reservations().Purge(reservation.ID); err != nil {
+				return err
+			}
+			result.Reservations++
+		}
+
+		rooms, err := tx.Rooms().FindDeletedBefore(cutoff)
+		if err != nil {
+			return err
+		}
+		for _, room := range rooms {
+			kept, err := tx.Reservations().ExistsInRoom(room.ID)
+			if err != nil {
+				return err
+			}
+			if kept {
+				continue
+			}
+			if err := tx.Rooms().Purge(room.ID); err != nil {
+				return err
+			}
+			result.Rooms++
+		}
+
+		users, err := tx.Users().FindDeletedBefore(cutoff)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			kept, err := tx.Reservations().ExistsForUser(user.ID)
+			if err != nil {
+				return err
+			}
+			if kept {
+				continue
+			}
+			if err := tx.Users().Purge(user.ID); err != nil {
+				return err
+			}
+			result.Users++
+		}
+		return nil
+	})
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	return result, nil
+}
+
+// hasFinancialRecords reports whether money was charged, paid or invoiced
+// for a reservation.
+func hasFinancialRecords(store repository.Store, reservationID uint) (bool, error) {
+	_, err := store.Folios().FindByReservation(reservationID)
+	if !errors.Is(err, repository.ErrNotFound) {
+		return err == nil, err
+	}
+	payments, err := store.Payments().FindByReservation(reservationID)
+	if err != nil || len(payments) > 0 {
+		return len(payments) > 0, err
+	}
+	_, err = store.Invoices().FindByReservation(reservationID)
+	if !errors.Is(err, repository.ErrNotFound) {
+		return err == nil, err
+	}
+	return false, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"testing"
+	"time"
+)
+
+func TestDeleteAndRestoreRoom(t *testing.T) {
+	store := newTestStore(t)
+	today := dayOf(time.Now())
+	bookStay(t, store, 1, today.AddDate(0, 0, 1), today.AddDate(0, 0, 3))
+
+	if err := DeleteRoom(store, 1, 1); !errors.Is(err, ErrRoomInUse) {
+		t.Errorf("deleting a booked room: got %v, want %v", err, ErrRoomInUse)
+	}
+	if err := DeleteRoom(store, 2, 7); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Errorf("deleting at a stale version: got %v, want %v", err, repository.ErrVersionConflict)
+	}
+	if err := DeleteRoom(store, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Rooms().FindByID(2); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("deleted room: got %v, want %v", err, repository.ErrNotFound)
+	}
+
+	room, err := RestoreRoom(store, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if room.Number != "102" {
+		t.Errorf("restored room %s, want 102", room.Number)
+	}
+	if _, err := RestoreRoom(store, 2); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("restoring a room that is not deleted: got %v, want %v", err, repository.ErrNotFound)
+	}
+}
+
+// TestRestoreReservation restores stays whose nights were sold again or
+// whose room was deleted in the meantime; neither comes back.
+func TestRestoreReservation(t *testing.T) {
+	store := newTestStore(t)
+	first := book(t, store, 1, "2026-01-05", "2026-01-07")
+	if err := store.Reservations().Delete(first.ID, first.Version); err != nil {
+		t.Fatal(err)
+	}
+	again := book(t, store, 1, "2026-01-06", "2026-01-08")
+
+	if _, err := RestoreReservation(store, first.ID); !errors.Is(err, ErrRoomUnavailable) {
+		t.Errorf("nights sold again: got %v, want %v", err, ErrRoomUnavailable)
+	}
+	if _, err := store.Reservations().FindByID(first.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("refused restore: got %v, want the reservation still deleted", err)
+	}
+
+	if err := store.Reservations().Delete(again.ID, again.Version); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := RestoreReservation(store, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Nights) != 2 || restored.TotalAmount != first.TotalAmount {
+		t.Errorf("got %d nights at %d, want the booked 2 at %d", len(restored.Nights), restored.TotalAmount, first.TotalAmount)
+	}
+
+	other := book(t, store, 2, "2026-01-05", "2026-01-07")
+	if err := store.Reservations().Delete(other.ID, other.Version); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Rooms().Delete(2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RestoreReservation(store, other.ID); !errors.Is(err, ErrRoomDeleted) {
+		t.Errorf("room deleted: got %v, want %v", err, ErrRoomDeleted)
+	}
+}
+
+// TestPurgeDeleted purges a deleted stay, but keeps one that was paid for
+// along with its room and guest, who are still referred to.
+func TestPurgeDeleted(t *testing.T) {
+	store := newTestStore(t)
+	unpaid := book(t, store, 1, "2026-01-05", "2026-01-07")
+	paid := book(t, store, 2, "2026-01-05", "2026-01-07")
+	if err := store.Payments().Create(&models.Payment{ReservationID: paid.ID, Kind: "deposit", Status: "captured", Amount: 5000, Currency: "USD"}); err != nil {
+		t.Fatal(err)
+	}
+	other := &models.User{Username: "other", Email: "other@example.com", Role: "customer"}
+	if err := store.Users().Create(other); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []uint{unpaid.ID, paid.ID} {
+		if err := store.Reservations().Delete(id, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Rooms().Delete(2, 1); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []uint{1, other.ID} {
+		if err := store.Users().Delete(id, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if result, err := PurgeDeleted(store, time.Hour); err != nil || result != (PurgeResult{}) {
+		t.Errorf("within retention: got %+v, %v, want nothing purged", result, err)
+	}
+	result, err := PurgeDeleted(store, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (PurgeResult{Users: 1, Reservations: 1}) {
+		t.Errorf("got %+v, want the unpaid stay and the other user purged", result)
+	}
+	if err := store.Reservations().Restore(unpaid.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("restoring a purged stay: got %v, want %v", err, repository.ErrNotFound)
+	}
+	if err := store.Reservations().Restore(paid.ID); err != nil {
+		t.Errorf("restoring the paid stay: %v", err)
+	}
+}
+
+func TestConfiguredDeletedRetention(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		err   error
+	}{
+		{value: "", want: DefaultDeletedRetention},
+		{value: "30", want: 30 * 24 * time.Hour},
+		{value: "0", want: 0},
+		{value: "-1", err: ErrInvalidRetention},
+		{value: "1.5", err: ErrInvalidRetention},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("DELETED_RETENTION_DAYS", tt.value)
+			got, err := ConfiguredDeletedRetention()
+			if !errors.Is(err, tt.err) || got != tt.want {
+				t.Errorf("got %s, %v, want %s, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"time"
+)
+
+var (
+	ErrInvalidCharge      = errors.New("invalid folio charge")
+	ErrOutstandingBalance = errors.New("folio balance is not settled")
+)
+
+// folioCategories are the kinds of lines a folio accepts.
+var folioCategories = map[string]bool{
+	"room":          true,
+	"minibar":       true,
+	"room-service":  true,
+	"parking":       true,
+	"late-checkout": true,
+	"payment":       true,
+	"refund":        true,
+	"cancellation":  true,
+	"adjustment":    true,
+}
+
+// ValidateCharge checks the type, category and amount of a folio line.
+func ValidateCharge(line *models.FolioLine) error {
+	if line.Type != "debit" && line.Type != "credit" {
+		return ErrInvalidCharge
+	}
+	if !folioCategories[line.Category] || line.Amount <= 0 {
+		return ErrInvalidCharge
+	}
+	return nil
+}
+
+// PostCharge adds a line to the folio of a reservation, opening the folio on
+// the first charge.
+func PostCharge(store repository.Store, reservationID uint, line *models.FolioLine, actorID uint) error {
+	if err := ValidateCharge(line); err != nil {
+		return err
+	}
+	return store.Transaction(func(tx repository.Store) error {
+		reservation, err := tx.Reservations().FindByIDForUpdate(reservationID)
+		if err != nil {
+			return err
+		}
+		line.PostedBy = actorID
+		return postLine(tx, reservation, line)
+	})
+}
+
+// postLine appends line to the folio of reservation and moves the running
+// balance. The caller must hold the reservation row lock, which keeps two
+// postings from opening the same folio twice.
+func postLine(tx repository.Store, reservation *models.Reservation, line *models.FolioLine) error {
+	folio, err := tx.Folios().FindByReservationForUpdate(reservation.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		folio = &models.Folio{
+			ReservationID: reservation.ID,
+			Currency:      reservation.Currency,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+		err = tx.Folios().Create(folio)
+	}
+	if err != nil {
+		return err
+	}
+
+	if line.Type == "credit" {
+		folio.Balance -= line.Amount
+	} else {
+		folio.Balance += line.Amount
+	}
+	folio.UpdatedAt = time.Now()
+
+	line.ID = 0
+	line.FolioID = folio.ID
+	line.Balance = folio.Balance
+	line.CreatedAt = time.Now()
+	if err := tx.Folios().CreateLine(line); err != nil {
+		return err
+	}
+	return tx.Folios().Save(folio)
+}
+
+// folioBalance returns the balance of a reservation, zero when nothing was
+// ever posted.
+func folioBalance(tx repository.Store, reservationID uint) (int64, error) {
+	folio, err := tx.Folios().FindByReservationForUpdate(reservationID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return folio.Balance, nil
+}
+
+// GetFolio returns the folio of a reservation with its lines. A reservation
+// without postings gets an empty folio.
+func GetFolio(store repository.Store, reservationID uint) (*models.Folio, error) {
+	reservation, err := store.Reservations().FindByID(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	folio, err := store.Folios().FindByReservation(reservationID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &models.Folio{ReservationID: reservation.ID, Currency: reservation.Currency, Lines: []models.FolioLine{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	folio.Lines, err = store.Folios().FindLines(folio.ID)
+	if err != nil {
+		return nil, err
+	}
+	return folio, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"testing"
+	"time"
+)
+
+// checkIn books roomID from today for two nights at 100 a night, confirms
+// the stay and checks it in, which posts the room charge.
+func checkIn(t *testing.T, store repository.Store, roomID uint) *models.Reservation {
+	t.Helper()
+	today := dayOf(time.Now())
+	reservation := bookStay(t, store, roomID, today, today.AddDate(0, 0, 2))
+	reservation.Status = "confirmed"
+	if err := SaveReservation(store, reservation, 1); err != nil {
+		t.Fatal(err)
+	}
+	reservation, err := CheckIn(store, reservation.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reservation
+}
+
+func TestPostCharge(t *testing.T) {
+	store := newTestStore(t)
+	reservation := book(t, store, 1, "2026-01-05", "2026-01-07")
+
+	// The cases post to the same folio in order, so Balance runs on.
+	tests := []struct {
+		name    string
+		line    models.FolioLine
+		err     error
+		balance int64
+	}{
+		{name: "debit", line: models.FolioLine{Type: "debit", Category: "minibar", Amount: 500}, balance: 500},
+		{name: "credit", line: models.FolioLine{Type: "credit", Category: "payment", Amount: 200}, balance: 300},
+		{name: "unknown type", line: models.FolioLine{Type: "refund", Category: "payment", Amount: 200}, err: ErrInvalidCharge, balance: 300},
+		{name: "unknown category", line: models.FolioLine{Type: "debit", Category: "spa", Amount: 200}, err: ErrInvalidCharge, balance: 300},
+		{name: "zero amount", line: models.FolioLine{Type: "debit", Category: "parking", Amount: 0}, err: ErrInvalidCharge, balance: 300},
+		{name: "negative amount", line: models.FolioLine{Type: "credit", Category: "adjustment", Amount: -50}, err: ErrInvalidCharge, balance: 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := tt.line
+			if err := PostCharge(store, reservation.ID, &line, 1); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			folio, err := GetFolio(store, reservation.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if folio.Balance != tt.balance {
+				t.Errorf("got balance %d, want %d", folio.Balance, tt.balance)
+			}
+			if tt.err == nil && line.Balance != tt.balance {
+				t.Errorf("got line balance %d, want %d", line.Balance, tt.balance)
+			}
+		})
+	}
+}
+
+func TestCheckOutRequiresSettledFolio(t *testing.T) {
+	store := newTestStore(t)
+	reservation := checkIn(t, store, 1)
+
+	folio, err := GetFolio(store, reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folio.Balance != 20000 || len(folio.Lines) != 1 || folio.Lines[0].Category != "room" {
+		t.Fatalf("got folio %+v, want the 20000 room charge", folio)
+	}
+
+	if _, err := CheckOut(store, reservation.ID, 1, false); !errors.Is(err, ErrOutstandingBalance) {
+		t.Fatalf("got %v, want %v", err, ErrOutstandingBalance)
+	}
+	payment := &models.FolioLine{Type: "credit", Category: "payment", Amount: 20000}
+	if err := PostCharge(store, reservation.ID, payment, 1); err != nil {
+		t.Fatal(err)
+	}
+	if reservation, err = CheckOut(store, reservation.ID, 1, false); err != nil {
+		t.Fatal(err)
+	}
+	if reservation.Status != "checked-out" {
+		t.Errorf("got status %s, want checked-out", reservation.Status)
+	}
+}
+
+func TestCheckOutOverride(t *testing.T) {
+	store := newTestStore(t)
+	reservation := checkIn(t, store, 1)
+
+	reservation, err := CheckOut(store, reservation.ID, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reservation.Status != "checked-out" {
+		t.Errorf("got status %s, want checked-out", reservation.Status)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"time"
+)
+
+var (
+	ErrOutsideStayWindow      = errors.New("reservation cannot be checked in outside its stay dates")
+	ErrRoomNotReady           = errors.New("room is not ready for check-in")
+	ErrStatusRequiresWorkflow = errors.New("status can only be set through check-in, check-out or cancel")
+)
+
+// sameOrAfterDay reports whether t falls on or after the calendar day of day.
+func sameOrAfterDay(t, day time.Time) bool {
+	ty, tm, td := t.UTC().Date()
+	dy, dm, dd := day.UTC().Date()
+	return !time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC).Before(time.Date(dy, dm, dd, 0, 0, 0, 0, time.UTC))
+}
+
+// CheckIn moves a confirmed reservation to checked-in, marks its room as
+// occupied and posts the room charge to the folio in one transaction. Guests
+// may check in from the arrival day until the day before departure, and only
+// into a room that is available.
+func CheckIn(store repository.Store, id uint, actorID uint) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := store.Transaction(func(tx repository.Store) error {
+		var err error
+		reservation, err = tx.Reservations().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if err := ValidateTransition(reservation.Status, "checked-in"); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if !sameOrAfterDay(now, reservation.StartDate) || sameOrAfterDay(now, reservation.EndDate) {
+			return ErrOutsideStayWindow
+		}
+
+		room, err := lockRoom(tx, reservation.RoomID)
+		if err != nil {
+			return err
+		}
+		if room.Status != "available" {
+			return ErrRoomNotReady
+		}
+		room.Status = "occupied"
+		room.UpdateAt = now
+		if err := tx.Rooms().Save(room); err != nil {
+			return err
+		}
+
+		if reservation.TotalAmount > 0 {
+			charge := &models.FolioLine{
+				Type:        "debit",
+				Category:    "room",
+				Description: "Room charge",
+				Amount:      reservation.TotalAmount,
+				PostedBy:    actorID,
+			}
+			if err := postLine(tx, reservation, charge); err != nil {
+				return err
+			}
+		}
+
+		reservation.Status = "checked-in"
+		StampStatus(reservation, actorID)
+		return SaveReservation(tx, reservation, actorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// adjustRoomCharge posts the difference between the old and the new price of
+// a checked-in stay to its folio, which was charged the old price at
+// check-in: a debit when the stay got dearer, a credit when it got cheaper.
+func adjustRoomCharge(tx repository.Store, before, after *models.Reservation, actorID uint) error {
+	if before.Status != "checked-in" || after.Status != "checked-in" || after.TotalAmount == before.TotalAmount {
+		return nil
+	}
+	adjustment := &models.FolioLine{
+		Type:        "debit",
+		Category:    "room",
+		Description: "Room charge adjustment",
+		Amount:      after.TotalAmount - before.TotalAmount,
+		PostedBy:    actorID,
+	}
+	if adjustment.Amount < 0 {
+		adjustment.Type = "credit"
+		adjustment.Amount = -adjustment.Amount
+	}
+	return postLine(tx, after, adjustment)
+}
+
+// CheckOut moves a checked-in reservation to checked-out, sends its room to
+// cleaning, opens a housekeeping task for it and issues its invoice in one
+// transaction. The folio must be settled first unless override is set.
+func CheckOut(store repository.Store, id uint, actorID uint, override bool) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := store.Transaction(func(tx repository.Store) error {
+		var err error
+		reservation, err = tx.Reservations().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if err := ValidateTransition(reservation.Status, "checked-out"); err != nil {
+			return err
+		}
+
+		balance, err := folioBalance(tx, reservation.ID)
+		if err != nil {
+			return err
+		}
+		if balance != 0 && !override {
+			return ErrOutstandingBalance
+		}
+
+		room, err := lockRoom(tx, reservation.RoomID)
+		if err != nil {
+			return err
+		}
+		room.Status = "cleaning"
+		room.UpdateAt = time.Now()
+		if err := tx.Rooms().Save(room); err != nil {
+			return err
+		}
+		if err := createCleaningTask(tx, room.ID, reservation.ID); err != nil {
+			return err
+		}
+
+		reservation.Status = "checked-out"
+		StampStatus(reservation, actorID)
+		if err := SaveReservation(tx, reservation, actorID); err != nil {
+			return err
+		}
+		_, err = issueInvoice(tx, reservation, actorID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
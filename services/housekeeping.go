@@ -0,0 +1,159 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"time"
+)
+
+var (
+	ErrTaskAlreadyClaimed = errors.New("housekeeping task is claimed by someone else")
+	ErrTaskDone           = errors.New("housekeeping task is already done")
+)
+
+// createCleaningTask opens a housekeeping task for a room that has just been
+// vacated by reservationID.
+func createCleaningTask(store repository.Store, roomID, reservationID uint) error {
+	now := time.Now()
+	return store.HousekeepingTasks().Create(&models.HousekeepingTask{
+		RoomID:        roomID,
+		ReservationID: reservationID,
+		Status:        "open",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+}
+
+// ClaimTask assigns an open task to a housekeeper. Claiming a task one
+// already holds is a no-op.
+func ClaimTask(store repository.Store, id uint, userID uint) (*models.HousekeepingTask, error) {
+	var task *models.HousekeepingTask
+	err := store.Transaction(func(tx repository.Store) error {
+		var err error
+		task, err = tx.HousekeepingTasks().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case task.Status == "done":
+			return ErrTaskDone
+		case task.Status == "claimed" && task.AssignedTo == userID:
+			return nil
+		case task.Status == "claimed":
+			return ErrTaskAlreadyClaimed
+		}
+
+		now := time.Now()
+		task.Status = "claimed"
+		task.AssignedTo = userID
+		task.ClaimedAt = &now
+		task.UpdatedAt = now
+		return tx.HousekeepingTasks().Save(task)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// CompleteTask marks a task as done and the room as clean and available.
+// Housekeepers may only complete tasks that are unclaimed or their own;
+// supervisors (override) may complete any task.
+func CompleteTask(store repository.Store, id uint, userID uint, override bool) (*models.HousekeepingTask, error) {
+	var task *models.HousekeepingTask
+	err := store.Transaction(func(tx repository.Store) error {
+		var err error
+		task, err = tx.HousekeepingTasks().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if task.Status == "done" {
+			return ErrTaskDone
+		}
+		if task.Status == "claimed" && task.AssignedTo != userID && !override {
+			return ErrTaskAlreadyClaimed
+		}
+
+		now := time.Now()
+		if task.AssignedTo == 0 {
+			task.AssignedTo = userID
+			task.ClaimedAt = &now
+		}
+		task.Status = "done"
+		task.CompletedAt = &now
+		task.UpdatedAt = now
+		if err := tx.HousekeepingTasks().Save(task); err != nil {
+			return err
+		}
+
+		room, err := tx.Rooms().FindByIDForUpdate(task.RoomID)
+		if err != nil {
+			return err
+		}
+		// A guest may already have been checked into the room; only a room
+		// that is waiting for housekeeping becomes available.
+		if room.Status == "cleaning" {
+			room.Status = "available"
+			room.UpdateAt = now
+			return tx.Rooms().Save(room)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// BoardEntry is the cleaning state of one room on the housekeeping board.
+type BoardEntry struct {
+	RoomID     uint                      `json:"room_id"`
+	Number     string                    `json:"number"`
+	RoomStatus string                    `json:"room_status"`
+	Tasks      []models.HousekeepingTask `json:"tasks"`
+}
+
+// HousekeepingBoard lists every room with the tasks relevant to day: those
+// created on or before it that were still outstanding at some point that day.
+func HousekeepingBoard(store repository.Store, day time.Time) ([]BoardEntry, error) {
+	y, m, d := day.UTC().Date()
+	dayStart := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	rooms, err := store.Rooms().FindAll()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := store.HousekeepingTasks().FindAll("")
+	if err != nil {
+		return nil, err
+	}
+
+	byRoom := make(map[uint][]models.HousekeepingTask)
+	for _, task := range tasks {
+		if !task.CreatedAt.Before(dayEnd) {
+			continue
+		}
+		if task.CompletedAt != nil && task.CompletedAt.Before(dayStart) {
+			continue
+		}
+		byRoom[task.RoomID] = append(byRoom[task.RoomID], task)
+	}
+
+	board := make([]BoardEntry, 0, len(rooms))
+	for _, room := range rooms {
+		entry := BoardEntry{
+			RoomID:     room.ID,
+			Number:     room.Number,
+			RoomStatus: room.Status,
+			Tasks:      byRoom[room.ID],
+		}
+		if entry.Tasks == nil {
+			entry.Tasks = []models.HousekeepingTask{}
+		}
+		board = append(board, entry)
+	}
+	return board, nil
+}
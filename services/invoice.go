@@ -0,0 +1,200 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNoInvoice = errors.New("reservation has not been invoiced")
+
+// invoiceNumber formats the sequence number of an invoice within its year.
+func invoiceNumber(year, sequence int) string {
+	return fmt.Sprintf("%d-%06d", year, sequence)
+}
+
+// invoiceLines lists what a reservation is billed for and what was paid:
+// its nights, their taxes summed per tax, the extras and penalties posted to
+// the folio, then the payments and refunds. The room charge posted at
+// check-in and its adjustments are left out because the nights and taxes
+// already itemize them.
+func invoiceLines(tx repository.Store, reservation *models.Reservation) ([]models.InvoiceLine, error) {
+	var lines []models.InvoiceLine
+	for _, night := range reservation.Nights {
+		date := night.Date
+		lines = append(lines, models.InvoiceLine{
+			Kind:        "night",
+			Description: "Night of " + date.Format("2006-01-02"),
+			Date:        &date,
+			Amount:      night.Amount,
+		})
+	}
+
+	taxLines := make(map[string]int)
+	for _, tax := range reservation.Taxes {
+		i, ok := taxLines[tax.Name]
+		if !ok {
+			description := tax.Name
+			if tax.Inclusive {
+				description += " (included)"
+			}
+			i = len(lines)
+			taxLines[tax.Name] = i
+			lines = append(lines, models.InvoiceLine{Kind: "tax", Description: description, Included: tax.Inclusive})
+		}
+		lines[i].Amount += tax.Amount
+	}
+
+	folio, err := GetFolio(tx, reservation.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range folio.Lines {
+		created := line.CreatedAt
+		invoiceLine := models.InvoiceLine{Description: line.Description, Date: &created, Amount: line.Amount}
+		switch {
+		case line.Category == "room":
+			continue
+		case line.Category == "refund":
+			invoiceLine.Kind = "refund"
+		case line.Type == "credit":
+			invoiceLine.Kind = "payment"
+		default:
+			invoiceLine.Kind = "extra"
+		}
+		if invoiceLine.Description == "" {
+			invoiceLine.Description = line.Category
+		}
+		lines = append(lines, invoiceLine)
+	}
+	return lines, nil
+}
+
+// issueInvoice bills a reservation with the next number of the current year.
+// It must run inside the transaction that checks the reservation out so
+// that the invoice and its number are only kept if the check-out is.
+func issueInvoice(tx repository.Store, reservation *models.Reservation, actorID uint) (*models.Invoice, error) {
+	lines, err := invoiceLines(tx, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invoice := &models.Invoice{
+		Year:          now.UTC().Year(),
+		ReservationID: reservation.ID,
+		UserID:        reservation.UserID,
+		Currency:      reservation.Currency,
+		Lines:         lines,
+		IssuedBy:      actorID,
+		IssuedAt:      now,
+	}
+	for _, line := range lines {
+		switch line.Kind {
+		case "night", "extra":
+			invoice.Subtotal += line.Amount
+		case "tax":
+			// Included taxes are already in the night amounts, which the
+			// subtotal should show net of tax.
+			invoice.TaxTotal += line.Amount
+			if line.Included {
+				invoice.Subtotal -= line.Amount
+			}
+		case "payment":
+			invoice.Paid += line.Amount
+		case "refund":
+			invoice.Paid -= line.Amount
+		}
+	}
+	invoice.Total = invoice.Subtotal + invoice.TaxTotal
+	invoice.BalanceDue = invoice.Total - invoice.Paid
+
+	invoice.Sequence, err = tx.Invoices().NextNumber(invoice.Year)
+	if err != nil {
+		return nil, err
+	}
+	invoice.Number = invoiceNumber(invoice.Year, invoice.Sequence)
+	if err := tx.Invoices().Create(invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// FindInvoiceByReservation returns the invoice issued when a reservation
+// was checked out.
+func FindInvoiceByReservation(store repository.Store, reservationID uint) (*models.Invoice, error) {
+	if _, err := store.Reservations().FindByID(reservationID); err != nil {
+		return nil, err
+	}
+	invoice, err := store.Invoices().FindByReservation(reservationID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrNoInvoice
+	}
+	return invoice, err
+}
+
+// FormatAmount prints an amount in minor units with its currency.
+func FormatAmount(amount int64, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	units := int64(minorUnits(currency))
+	if units == 1 {
+		return fmt.Sprintf("%s%d %s", sign, amount, currency)
+	}
+	digits := len(strconv.FormatInt(units, 10)) - 1
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/units, digits, amount%units, currency)
+}
+
+// RenderInvoicePDF lays an invoice out as a printable PDF document.
+func RenderInvoicePDF(invoice *models.Invoice) []byte {
+	row := func(label string, amount int64) string {
+		return fmt.Sprintf("%-56s %20s", label, FormatAmount(amount, invoice.Currency))
+	}
+	section := func(title string, kinds ...string) []string {
+		var rows []string
+		for _, line := range invoice.Lines {
+			for _, kind := range kinds {
+				if line.Kind == kind {
+					rows = append(rows, row("  "+line.Description, line.Amount))
+				}
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return append([]string{title}, append(rows, "")...)
+	}
+
+	lines := []string{
+		"INVOICE " + invoice.Number,
+		"",
+		"Issued:      " + invoice.IssuedAt.UTC().Format("2006-01-02 15:04 MST"),
+		fmt.Sprintf("Reservation: #%d", invoice.ReservationID),
+		fmt.Sprintf("Guest:       #%d", invoice.UserID),
+		"",
+		strings.Repeat("-", 77),
+	}
+	lines = append(lines, section("Accommodation", "night")...)
+	lines = append(lines, section("Extras", "extra")...)
+	lines = append(lines, section("Taxes", "tax")...)
+	lines = append(lines,
+		strings.Repeat("-", 77),
+		row("Subtotal", invoice.Subtotal),
+		row("Tax", invoice.TaxTotal),
+		row("Total", invoice.Total),
+		"",
+	)
+	lines = append(lines, section("Payments", "payment", "refund")...)
+	lines = append(lines,
+		row("Paid", invoice.Paid),
+		row("Balance due", invoice.BalanceDue),
+	)
+	return textPDF(lines)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hotel_management_system/models"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckOutIssuesInvoice(t *testing.T) {
+	store := newTestStore(t)
+	reservation := checkIn(t, store, 1)
+	for _, line := range []models.FolioLine{
+		{Type: "debit", Category: "minibar", Description: "Minibar", Amount: 500},
+		{Type: "credit", Category: "payment", Description: "Cash", Amount: 20500},
+	} {
+		if err := PostCharge(store, reservation.ID, &line, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := CheckOut(store, reservation.ID, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, err := FindInvoiceByReservation(store, reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("%d-000001", time.Now().UTC().Year()); invoice.Number != want {
+		t.Errorf("got number %s, want %s", invoice.Number, want)
+	}
+	var kinds []string
+	for _, line := range invoice.Lines {
+		kinds = append(kinds, line.Kind)
+	}
+	if fmt.Sprint(kinds) != "[night night extra payment]" {
+		t.Errorf("got lines %v, want two nights, the minibar and the payment", kinds)
+	}
+	if invoice.Subtotal != 20500 || invoice.Total != 20500 || invoice.Paid != 20500 || invoice.BalanceDue != 0 {
+		t.Errorf("got subtotal %d, total %d, paid %d, due %d, want 20500, 20500, 20500, 0",
+			invoice.Subtotal, invoice.Total, invoice.Paid, invoice.BalanceDue)
+	}
+}
+
+func TestCheckOutRefusedIssuesNoInvoice(t *testing.T) {
+	store := newTestStore(t)
+	reservation := checkIn(t, store, 1)
+
+	if _, err := CheckOut(store, reservation.ID, 1, false); !errors.Is(err, ErrOutstandingBalance) {
+		t.Fatalf("got %v, want %v", err, ErrOutstandingBalance)
+	}
+	if _, err := FindInvoiceByReservation(store, reservation.ID); !errors.Is(err, ErrNoInvoice) {
+		t.Errorf("got %v, want %v", err, ErrNoInvoice)
+	}
+}
+
+// TestInvoiceNumbersConcurrent checks out several stays at once. Every
+// invoice must get its own number, with no gaps.
+func TestInvoiceNumbersConcurrent(t *testing.T) {
+	store := newTestStore(t)
+	const stays = 6
+	var reservations []*models.Reservation
+	for i := 0; i < stays; i++ {
+		room := &models.Room{Number: fmt.Sprintf("2%02d", i), RoomTypeID: 1, Status: "available"}
+		if err := store.Rooms().Create(room); err != nil {
+			t.Fatal(err)
+		}
+		reservations = append(reservations, checkIn(t, store, room.ID))
+	}
+
+	var wg sync.WaitGroup
+	for _, reservation := range reservations {
+		wg.Add(1)
+		go func(id uint) {
+			defer wg.Done()
+			if _, err := CheckOut(store, id, 1, true); err != nil {
+				t.Error(err)
+			}
+		}(reservation.ID)
+	}
+	wg.Wait()
+
+	var sequences []int
+	for _, reservation := range reservations {
+		invoice, err := FindInvoiceByReservation(store, reservation.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sequences = append(sequences, invoice.Sequence)
+	}
+	sort.Ints(sequences)
+	for i, sequence := range sequences {
+		if sequence != i+1 {
+			t.Fatalf("got sequences %v, want 1 to %d", sequences, stays)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount int64
+		want   string
+	}{
+		{amount: 12345, want: "123.45 USD"},
+		{amount: 5, want: "0.05 USD"},
+		{amount: -250, want: "-2.50 USD"},
+		{amount: 0, want: "0.00 USD"},
+	}
+	for _, tt := range tests {
+		if got := FormatAmount(tt.amount, "USD"); got != tt.want {
+			t.Errorf("FormatAmount(%d): got %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestRenderInvoicePDF(t *testing.T) {
+	invoice := &models.Invoice{Number: "2026-000001", Currency: "USD", Total: 20000, Lines: []models.InvoiceLine{{Kind: "night", Description: "Night of 2026-01-05", Amount: 20000}}}
+	pdf := RenderInvoicePDF(invoice)
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.Contains(pdf, []byte("INVOICE 2026-000001")) {
+		t.Errorf("got %q, want a PDF of the invoice", pdf)
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"log"
+	"time"
+)
+
+// NightlyJobHour is the local hour at which RunNightly runs its job.
+const NightlyJobHour = 3
+
+// RunNightly calls job every night at NightlyJobHour local time, logging
+// its failures. It never returns and is meant to run in its own goroutine.
+func RunNightly(name string, job func() error) {
+	for {
+		time.Sleep(untilHour(time.Now(), NightlyJobHour))
+		if err := job(); err != nil {
+			log.Printf("Nightly job %s failed: %v", name, err)
+		}
+	}
+}
+
+// untilHour returns the time from now to the next hour:00 local time.
+func untilHour(now time.Time, hour int) time.Duration {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Sub(now)
+}
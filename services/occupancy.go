@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+)
+
+var (
+	ErrInvalidGuests    = errors.New("a reservation needs at least one adult and no negative guest counts")
+	ErrOverOccupancy    = errors.New("too many guests for the room type")
+	ErrInvalidOccupancy = errors.New("invalid room occupancy")
+)
+
+// NormalizeRoomOccupancy defaults the base occupancy of a room type to its
+// maximum, with every guest included in the price, and checks that the
+// result is consistent.
+func NormalizeRoomOccupancy(roomType *models.RoomType) error {
+	if roomType.BaseOccupancy == 0 {
+		roomType.BaseOccupancy = roomType.MaxOccupancy
+	}
+	if roomType.MaxOccupancy < 1 || roomType.BaseOccupancy < 1 || roomType.BaseOccupancy > roomType.MaxOccupancy {
+		return ErrInvalidOccupancy
+	}
+	if roomType.ExtraAdultAmount < 0 || roomType.ExtraChildAmount < 0 {
+		return ErrInvalidOccupancy
+	}
+	return nil
+}
+
+// ValidateGuests checks the party of a reservation against the room type.
+func ValidateGuests(roomType *models.RoomType, reservation *models.Reservation) error {
+	if reservation.Adults < 1 || reservation.Children < 0 {
+		return ErrInvalidGuests
+	}
+	if reservation.Adults+reservation.Children > roomType.MaxOccupancy {
+		return ErrOverOccupancy
+	}
+	return nil
+}
+
+// guestCount is the number of guests a reservation is taxed for.
+func guestCount(reservation *models.Reservation) int {
+	return max(reservation.Adults+reservation.Children, 1)
+}
+
+// extraPersonAmount is the nightly surcharge for the guests beyond the base
+// occupancy of roomType. Adults take the included places first.
+func extraPersonAmount(roomType *models.RoomType, adults, children int) int64 {
+	extraAdults := max(adults-roomType.BaseOccupancy, 0)
+	extraChildren := max(children-max(roomType.BaseOccupancy-adults, 0), 0)
+	return int64(extraAdults)*roomType.ExtraAdultAmount + int64(extraChildren)*roomType.ExtraChildAmount
+}
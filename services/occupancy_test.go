@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"testing"
+)
+
+func TestNormalizeRoomOccupancy(t *testing.T) {
+	tests := []struct {
+		name      string
+		roomType  models.RoomType
+		max, base int
+		err       error
+	}{
+		{name: "base defaults to max", roomType: models.RoomType{MaxOccupancy: 3}, max: 3, base: 3},
+		{name: "explicit", roomType: models.RoomType{MaxOccupancy: 3, BaseOccupancy: 2}, max: 3, base: 2},
+		{name: "no occupancy", roomType: models.RoomType{}, err: ErrInvalidOccupancy},
+		{name: "base above max", roomType: models.RoomType{MaxOccupancy: 2, BaseOccupancy: 3}, err: ErrInvalidOccupancy},
+		{name: "negative surcharge", roomType: models.RoomType{MaxOccupancy: 2, ExtraChildAmount: -100}, err: ErrInvalidOccupancy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NormalizeRoomOccupancy(&tt.roomType)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if err == nil && (tt.roomType.MaxOccupancy != tt.max || tt.roomType.BaseOccupancy != tt.base) {
+				t.Errorf("got max %d base %d, want %d %d", tt.roomType.MaxOccupancy, tt.roomType.BaseOccupancy, tt.max, tt.base)
+			}
+		})
+	}
+}
+
+func TestExtraPersonAmount(t *testing.T) {
+	roomType := &models.RoomType{MaxOccupancy: 5, BaseOccupancy: 2, ExtraAdultAmount: 3000, ExtraChildAmount: 1500}
+	tests := []struct {
+		name             string
+		adults, children int
+		want             int64
+	}{
+		{name: "within the base occupancy", adults: 2, want: 0},
+		{name: "extra adult", adults: 3, want: 3000},
+		{name: "extra child", adults: 2, children: 1, want: 1500},
+		{name: "child takes a free place", adults: 1, children: 2, want: 1500},
+		{name: "extra adults and child", adults: 4, children: 1, want: 2*3000 + 1500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extraPersonAmount(roomType, tt.adults, tt.children); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveReservationOccupancy(t *testing.T) {
+	store := newTestStore(t)
+	roomType := &models.RoomType{Name: "triple", BasePrice: 10000, Currency: "USD", MaxOccupancy: 3, BaseOccupancy: 2, ExtraAdultAmount: 2500}
+	if err := store.RoomTypes().Create(roomType); err != nil {
+		t.Fatal(err)
+	}
+	room := &models.Room{Number: "301", RoomTypeID: roomType.ID, Status: "available"}
+	if err := store.Rooms().Create(room); err != nil {
+		t.Fatal(err)
+	}
+	reservation := book(t, store, room.ID, "2026-01-05", "2026-01-07")
+
+	tests := []struct {
+		name             string
+		adults, children int
+		err              error
+		total            int64
+	}{
+		{name: "second adult is included", adults: 2, total: 20000},
+		{name: "third adult pays the surcharge", adults: 3, total: 25000},
+		{name: "over the maximum", adults: 3, children: 1, err: ErrOverOccupancy},
+		{name: "children alone", children: 2, err: ErrInvalidGuests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := *reservation
+			update.Adults, update.Children = tt.adults, tt.children
+			if err := SaveReservation(store, &update, 1); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if update.TotalAmount != tt.total {
+				t.Errorf("got total %d, want %d", update.TotalAmount, tt.total)
+			}
+			reservation = &update
+		})
+	}
+
+	stored, err := store.Reservations().FindByID(reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Adults != 3 || stored.TotalAmount != 25000 || stored.Nights[0].ExtraPersonAmount != 2500 {
+		t.Errorf("stored %d adults at %d with %d extra a night, want 3 at 25000 with 2500",
+			stored.Adults, stored.TotalAmount, stored.Nights[0].ExtraPersonAmount)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrPaymentDeclined = errors.New("payment declined")
+	ErrUnknownPayment  = errors.New("unknown payment reference")
+)
+
+// PaymentGateway is the card processor the hotel takes money through. All
+// amounts are in minor units.
+type PaymentGateway interface {
+	// Authorize reserves amount on a payment source, such as a card token,
+	// and returns the reference used to capture and refund it.
+	Authorize(amount int64, currency string, source string) (string, error)
+	// Capture collects up to the authorized amount.
+	Capture(reference string, amount int64) error
+	// Refund returns up to the captured amount.
+	Refund(reference string, amount int64) error
+}
+
+// DeclinedSource is the payment source FakeGateway always declines.
+const DeclinedSource = "tok_declined"
+
+// FakeGateway is an in-process PaymentGateway for tests and local setups. It
+// accepts every source except DeclinedSource and never moves real money.
+type FakeGateway struct {
+	mu       sync.Mutex
+	next     int
+	payments map[string]*fakePayment
+}
+
+type fakePayment struct {
+	authorized int64
+	captured   int64
+	refunded   int64
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{payments: make(map[string]*fakePayment)}
+}
+
+func (g *FakeGateway) Authorize(amount int64, currency string, source string) (string, error) {
+	if source == DeclinedSource || amount <= 0 {
+		return "", ErrPaymentDeclined
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next++
+	reference := fmt.Sprintf("fake_%d", g.next)
+	g.payments[reference] = &fakePayment{authorized: amount}
+	return reference, nil
+}
+
+func (g *FakeGateway) Capture(reference string, amount int64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	payment, ok := g.payments[reference]
+	if !ok {
+		return ErrUnknownPayment
+	}
+	if payment.captured+amount > payment.authorized {
+		return ErrPaymentDeclined
+	}
+	payment.captured += amount
+	return nil
+}
+
+func (g *FakeGateway) Refund(reference string, amount int64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	payment, ok := g.payments[reference]
+	if !ok {
+		return ErrUnknownPayment
+	}
+	if payment.refunded+amount > payment.captured {
+		return ErrPaymentDeclined
+	}
+	payment.refunded += amount
+	return nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"math"
+	"time"
+)
+
+var (
+	ErrPaymentRequired = errors.New("a payment source is required for the deposit")
+	ErrInvalidPayment  = errors.New("invalid payment amount")
+)
+
+// DepositAmount is the part of the stay total a rate plan requires upfront.
+func DepositAmount(plan *models.RatePlan, reservation *models.Reservation) int64 {
+	return int64(math.Round(float64(reservation.TotalAmount) * plan.DepositPercent / 100))
+}
+
+// charge authorizes and captures amount on source.
+func charge(gateway PaymentGateway, kind string, amount int64, currency string, source string) (*models.Payment, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidPayment
+	}
+	if source == "" {
+		return nil, ErrPaymentRequired
+	}
+	reference, err := gateway.Authorize(amount, currency, source)
+	if err != nil {
+		return nil, err
+	}
+	if err := gateway.Capture(reference, amount); err != nil {
+		return nil, err
+	}
+	return &models.Payment{
+		Kind:      kind,
+		Status:    "captured",
+		Amount:    amount,
+		Currency:  currency,
+		Reference: reference,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// ChargeDeposit collects the deposit plan requires for a priced reservation
+// that is not saved yet. It returns nil when no deposit is due. The payment
+// is recorded with RecordPayment once the reservation exists, or returned
+// with VoidPayment if saving it fails.
+func ChargeDeposit(gateway PaymentGateway, plan *models.RatePlan, reservation *models.Reservation, source string) (*models.Payment, error) {
+	amount := DepositAmount(plan, reservation)
+	if amount == 0 {
+		return nil, nil
+	}
+	return charge(gateway, "deposit", amount, reservation.Currency, source)
+}
+
+// VoidPayment gives back a captured payment that could not be recorded.
+func VoidPayment(gateway PaymentGateway, payment *models.Payment) error {
+	return gateway.Refund(payment.Reference, payment.Amount)
+}
+
+// RecordPayment stores a captured payment against a reservation and credits
+// it to the folio.
+func RecordPayment(store repository.Store, reservationID uint, payment *models.Payment, actorID uint) error {
+	return store.Transaction(func(tx repository.Store) error {
+		reservation, err := tx.Reservations().FindByIDForUpdate(reservationID)
+		if err != nil {
+			return err
+		}
+
+		payment.ReservationID = reservation.ID
+		payment.CreatedBy = actorID
+		if err := tx.Payments().Create(payment); err != nil {
+			return err
+		}
+		return postLine(tx, reservation, &models.FolioLine{
+			Type:        "credit",
+			Category:    "payment",
+			Description: "Payment " + payment.Reference,
+			Amount:      payment.Amount,
+			PostedBy:    actorID,
+		})
+	})
+}
+
+// TakePayment charges amount to source for a reservation and records it.
+func TakePayment(store repository.Store, gateway PaymentGateway, reservationID uint, amount int64, source string, actorID uint) (*models.Payment, error) {
+	reservation, err := store.Reservations().FindByID(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := charge(gateway, "payment", amount, reservation.Currency, source)
+	if err != nil {
+		return nil, err
+	}
+	if err := RecordPayment(store, reservation.ID, payment, actorID); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// refundablePayments returns what is left to refund on each captured
+// payment and in total.
+func refundablePayments(payments []models.Payment) (map[uint]int64, int64) {
+	refundable := make(map[uint]int64)
+	var total int64
+	for _, payment := range payments {
+		switch {
+		case payment.Kind != "refund" && payment.Status == "captured":
+			refundable[payment.ID] += payment.Amount
+			total += payment.Amount
+		case payment.Kind == "refund" && payment.Status == "refunded":
+			refundable[payment.RefundOf] -= payment.Amount
+			total -= payment.Amount
+		}
+	}
+	return refundable, total
+}
+
+// RefundCancelled returns to the guest of a cancelled reservation the credit
+// left on the folio once the cancellation penalty is posted, newest payment
+// first and never more than went through the gateway. Each refund is
+// recorded and debited to the folio; a refund the gateway rejects is
+// recorded as failed and stops the run.
+func RefundCancelled(store repository.Store, gateway PaymentGateway, reservationID uint, actorID uint) ([]models.Payment, error) {
+	reservation, err := store.Reservations().FindByID(reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != "cancelled" {
+		return nil, ErrInvalidTransition
+	}
+	payments, err := store.Payments().FindByReservation(reservation.ID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := folioBalance(store, reservation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refundable, paid := refundablePayments(payments)
+	due := min(-balance, paid)
+	var refunds []models.Payment
+	for i := len(payments) - 1; i >= 0 && due > 0; i-- {
+		payment := payments[i]
+		amount := refundable[payment.ID]
+		if payment.Kind == "refund" || amount <= 0 {
+			continue
+		}
+		if amount > due {
+			amount = due
+		}
+
+		refund := models.Payment{
+			Kind:      "refund",
+			Status:    "refunded",
+			Amount:    amount,
+			Currency:  payment.Currency,
+			Reference: payment.Reference,
+			RefundOf:  payment.ID,
+			CreatedAt: time.Now(),
+		}
+		refundErr := gateway.Refund(payment.Reference, amount)
+		if refundErr != nil {
+			refund.Status = "failed"
+		}
+		if err := recordRefund(store, reservation.ID, &refund, actorID); err != nil {
+			return refunds, err
+		}
+		refunds = append(refunds, refund)
+		if refundErr != nil {
+			return refunds, refundErr
+		}
+		due -= amount
+	}
+	return refunds, nil
+}
+
+// recordRefund stores a refund and, when it went through, debits it to the
+// folio.
+func recordRefund(store repository.Store, reservationID uint, refund *models.Payment, actorID uint) error {
+	return store.Transaction(func(tx repository.Store) error {
+		reservation, err := tx.Reservations().FindByIDForUpdate(reservationID)
+		if err != nil {
+			return err
+		}
+
+		refund.ReservationID = reservation.ID
+		refund.CreatedBy = actorID
+		if err := tx.Payments().Create(refund); err != nil {
+			return err
+		}
+		if refund.Status != "refunded" {
+			return nil
+		}
+		return postLine(tx, reservation, &models.FolioLine{
+			Type:        "debit",
+			Category:    "refund",
+			Description: "Refund " + refund.Reference,
+			Amount:      refund.Amount,
+			PostedBy:    actorID,
+		})
+	})
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"reflect"
+	"testing"
+)
+
+// refusingGateway is a FakeGateway whose refunds are all declined.
+type refusingGateway struct {
+	*FakeGateway
+}
+
+func (refusingGateway) Refund(reference string, amount int64) error {
+	return ErrPaymentDeclined
+}
+
+func TestDepositAmount(t *testing.T) {
+	tests := []struct {
+		percent float64
+		total   int64
+		want    int64
+	}{
+		{percent: 0, total: 20000, want: 0},
+		{percent: 30, total: 20000, want: 6000},
+		{percent: 100, total: 20000, want: 20000},
+		{percent: 33, total: 10001, want: 3300},
+		{percent: 12.5, total: 10004, want: 1251},
+	}
+	for _, tt := range tests {
+		plan := &models.RatePlan{DepositPercent: tt.percent}
+		if got := DepositAmount(plan, &models.Reservation{TotalAmount: tt.total}); got != tt.want {
+			t.Errorf("%v%% of %d: got %d, want %d", tt.percent, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestChargeDeposit(t *testing.T) {
+	reservation := &models.Reservation{TotalAmount: 20000, Currency: "USD"}
+	tests := []struct {
+		name    string
+		percent float64
+		source  string
+		want    int64
+		err     error
+	}{
+		{name: "no deposit due", percent: 0, source: "", want: 0},
+		{name: "charged", percent: 50, source: "tok_visa", want: 10000},
+		{name: "missing source", percent: 50, source: "", err: ErrPaymentRequired},
+		{name: "declined", percent: 50, source: DeclinedSource, err: ErrPaymentDeclined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &models.RatePlan{DepositPercent: tt.percent}
+			payment, err := ChargeDeposit(NewFakeGateway(), plan, reservation, tt.source)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			var got int64
+			if payment != nil {
+				got = payment.Amount
+			}
+			if got != tt.want {
+				t.Errorf("got deposit %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// cancelPaid books a stay at 200 under the plan with code, takes payments
+// of 50 and 30 for it through gateway and cancels it, posting the penalty of
+// the plan.
+func cancelPaid(t *testing.T, store repository.Store, gateway PaymentGateway, code string) *models.Reservation {
+	t.Helper()
+	plan, err := FindRatePlan(store, code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reservation := book(t, store, 1, "2026-01-05", "2026-01-07")
+	reservation.RatePlanID = plan.ID
+	if err := store.Reservations().Save(reservation); err != nil {
+		t.Fatal(err)
+	}
+	for _, amount := range []int64{5000, 3000} {
+		if _, err := TakePayment(store, gateway, reservation.ID, amount, "tok_visa", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reservation, _, err = CancelReservation(store, reservation.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reservation
+}
+
+func TestRefundCancelled(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    string
+		refunds []int64
+		balance int64
+	}{
+		{name: "flexible plan refunds the newest payment first", plan: "standard", refunds: []int64{3000, 5000}, balance: 0},
+		{name: "non-refundable plan keeps everything", plan: "non-refundable", refunds: nil, balance: 12000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			gateway := NewFakeGateway()
+			reservation := cancelPaid(t, store, gateway, tt.plan)
+
+			refunds, err := RefundCancelled(store, gateway, reservation.ID, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int64
+			for _, refund := range refunds {
+				got = append(got, refund.Amount)
+			}
+			if !reflect.DeepEqual(got, tt.refunds) {
+				t.Fatalf("got refunds %v, want %v", got, tt.refunds)
+			}
+			folio, err := GetFolio(store, reservation.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if folio.Balance != tt.balance {
+				t.Errorf("got balance %d, want %d", folio.Balance, tt.balance)
+			}
+		})
+	}
+}
+
+func TestRefundCancelledOnlyOnce(t *testing.T) {
+	store := newTestStore(t)
+	gateway := NewFakeGateway()
+	reservation := cancelPaid(t, store, gateway, "standard")
+
+	if _, err := RefundCancelled(store, gateway, reservation.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	refunds, err := RefundCancelled(store, gateway, reservation.ID, 1)
+	if err != nil || len(refunds) != 0 {
+		t.Errorf("got %v, %v on the second run, want no refunds", refunds, err)
+	}
+}
+
+func TestRefundCancelledStopsAtDeclinedRefund(t *testing.T) {
+	store := newTestStore(t)
+	gateway := refusingGateway{NewFakeGateway()}
+	reservation := cancelPaid(t, store, gateway, "standard")
+
+	refunds, err := RefundCancelled(store, gateway, reservation.ID, 1)
+	if !errors.Is(err, ErrPaymentDeclined) {
+		t.Fatalf("got %v, want %v", err, ErrPaymentDeclined)
+	}
+	if len(refunds) != 1 || refunds[0].Status != "failed" {
+		t.Fatalf("got refunds %+v, want one failed refund", refunds)
+	}
+	payments, err := store.Payments().FindByReservation(reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payments) != 3 {
+		t.Errorf("got %d payments, want the two payments and the failed refund", len(payments))
+	}
+	folio, err := GetFolio(store, reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folio.Balance != -8000 {
+		t.Errorf("got balance %d, want -8000", folio.Balance)
+	}
+}
+
+func TestRefundCancelledRequiresCancellation(t *testing.T) {
+	store := newTestStore(t)
+	reservation := book(t, store, 1, "2026-01-05", "2026-01-07")
+
+	if _, err := RefundCancelled(store, NewFakeGateway(), reservation.ID, 1); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("got %v, want %v", err, ErrInvalidTransition)
+	}
+}
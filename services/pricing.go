@@ -0,0 +1,230 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultRatePlan is the plan used when a booking does not name one.
+const DefaultRatePlan = "standard"
+
+var ErrUnknownRatePlan = errors.New("unknown rate plan")
+
+// StayDates returns the calendar day of every night of a stay, in UTC. A
+// same-day stay counts as one night.
+func StayDates(start, end time.Time) []time.Time {
+	y, m, d := start.UTC().Date()
+	first := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	nights := Nights(start, end)
+	dates := make([]time.Time, nights)
+	for i := range dates {
+		dates[i] = first.AddDate(0, 0, i)
+	}
+	return dates
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ValidWeekdays reports whether a comma separated weekday list only contains
+// known day names.
+func ValidWeekdays(weekdays string) bool {
+	if weekdays == "" {
+		return true
+	}
+	for _, name := range strings.Split(weekdays, ",") {
+		if _, ok := weekdayNames[strings.TrimSpace(strings.ToLower(name))]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// overrideMatches reports whether an override applies to the night of date.
+func overrideMatches(override models.RateOverride, date time.Time) bool {
+	if override.StartDate != nil && date.Before(dayOf(*override.StartDate)) {
+		return false
+	}
+	if override.EndDate != nil && date.After(dayOf(*override.EndDate)) {
+		return false
+	}
+	if override.Weekdays == "" {
+		return true
+	}
+	for _, name := range strings.Split(override.Weekdays, ",") {
+		if weekdayNames[strings.TrimSpace(strings.ToLower(name))] == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// overrideRank orders matching overrides by specificity: seasonal rules
+// beat year-round ones and day-of-week rules beat every-day ones.
+func overrideRank(override models.RateOverride) int {
+	rank := 0
+	if override.StartDate != nil || override.EndDate != nil {
+		rank += 2
+	}
+	if override.Weekdays != "" {
+		rank++
+	}
+	return rank
+}
+
+func dayOf(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// applyPlan adjusts a base nightly amount by the percentage of a rate plan
+// and adds its supplement, already converted to the currency of base.
+func applyPlan(base int64, plan *models.RatePlan, supplement int64) int64 {
+	adjusted := int64(math.Round(float64(base) * (1 + plan.PercentAdjustment/100)))
+	return adjusted + supplement
+}
+
+// PriceStay computes the per-night price of a stay for adults and children
+// in a room of roomType under plan, in the currency of the type. Each night
+// starts from the most specific rate override for the type, or its base
+// price when none matches, is adjusted by the plan and gets the
+// extra-person surcharge of the type added. Overrides and supplements are in
+// the base currency and are converted at the rates of the booking date,
+// today.
+func PriceStay(store repository.Store, roomType *models.RoomType, plan *models.RatePlan, start, end time.Time, adults, children int) ([]models.ReservationNight, error) {
+	overrides, err := store.Rates().FindOverridesByRoomType(roomType.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := newConverter(store)
+	bookedAt := time.Now()
+	base := BaseCurrency()
+	supplement, err := conv.convert(plan.NightlySupplement, base, roomType.Currency, bookedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := extraPersonAmount(roomType, adults, children)
+	dates := StayDates(start, end)
+	nights := make([]models.ReservationNight, 0, len(dates))
+	for _, date := range dates {
+		amount := roomType.BasePrice
+		bestRank := -1
+		for _, override := range overrides {
+			// Later overrides win ties, so the newest rule takes effect.
+			if overrideMatches(override, date) && overrideRank(override) >= bestRank {
+				amount, err = conv.convert(override.Amount, base, roomType.Currency, bookedAt)
+				if err != nil {
+					return nil, err
+				}
+				bestRank = overrideRank(override)
+			}
+		}
+
+		nights = append(nights, models.ReservationNight{
+			Date:              date,
+			BaseAmount:        amount,
+			ExtraPersonAmount: extra,
+			Amount:            applyPlan(amount, plan, supplement) + extra,
+		})
+	}
+	return nights, nil
+}
+
+// FindRatePlan looks up a rate plan by code, defaulting to DefaultRatePlan.
+func FindRatePlan(store repository.Store, code string) (*models.RatePlan, error) {
+	if code == "" {
+		code = DefaultRatePlan
+	}
+	plan, err := store.Rates().FindPlanByCode(code)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrUnknownRatePlan
+	}
+	return plan, err
+}
+
+// PriceReservation fixes the nightly breakdown, taxes and total of a
+// reservation under plan. The stay is priced as its RoomTypeID, which
+// defaults to the type of its room.
+func PriceReservation(store repository.Store, reservation *models.Reservation, plan *models.RatePlan) error {
+	if reservation.RoomTypeID == 0 {
+		room, err := store.Rooms().FindByID(reservation.RoomID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrRoomNotFound
+		}
+		if err != nil {
+			return err
+		}
+		reservation.RoomTypeID = room.RoomTypeID
+	}
+	roomType, err := FindRoomType(store, reservation.RoomTypeID)
+	if err != nil {
+		return err
+	}
+	nights, err := PriceStay(store, roomType, plan, reservation.StartDate, reservation.EndDate, reservation.Adults, reservation.Children)
+	if err != nil {
+		return err
+	}
+	activeTaxes, err := ActiveTaxes(store, roomType.Currency)
+	if err != nil {
+		return err
+	}
+	total, taxes := stayTotal(activeTaxes, nights, guestCount(reservation))
+	reservation.RatePlanID = plan.ID
+	reservation.Nights = nights
+	reservation.Taxes = taxes
+	reservation.Currency = roomType.Currency
+	reservation.TotalAmount = total
+	reservation.TaxAmount, _ = SumTaxes(taxes)
+	return nil
+}
+
+// repriceReservation prices a reservation again under the rate plan it was
+// booked with.
+func repriceReservation(store repository.Store, reservation *models.Reservation) error {
+	plan, err := bookedRatePlan(store, reservation)
+	if err != nil {
+		return err
+	}
+	return PriceReservation(store, reservation, plan)
+}
+
+// bookedRatePlan returns the rate plan a reservation was booked with, or the
+// default plan when that one no longer exists.
+func bookedRatePlan(store repository.Store, reservation *models.Reservation) (*models.RatePlan, error) {
+	plan, err := store.Rates().FindPlanByID(reservation.RatePlanID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return FindRatePlan(store, DefaultRatePlan)
+	}
+	return plan, err
+}
+
+// stayChanged reports whether an update moves a reservation to another room
+// type or other dates, or changes its party, which invalidates its stored
+// price.
+func stayChanged(before, after *models.Reservation) bool {
+	return before.RoomTypeID != after.RoomTypeID || !before.StartDate.Equal(after.StartDate) || !before.EndDate.Equal(after.EndDate) ||
+		before.Adults != after.Adults || before.Children != after.Children
+}
+
+// SumNights returns the total amount of a nightly breakdown.
+func SumNights(nights []models.ReservationNight) int64 {
+	var total int64
+	for _, night := range nights {
+		total += night.Amount
+	}
+	return total
+}
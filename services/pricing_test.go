@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// day parses a YYYY-MM-DD date as midnight UTC.
+func day(t *testing.T, value string) time.Time {
+	t.Helper()
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return date
+}
+
+// amounts returns the Amount of every night of a breakdown.
+func amounts(nights []models.ReservationNight) []int64 {
+	result := make([]int64, len(nights))
+	for i, night := range nights {
+		result[i] = night.Amount
+	}
+	return result
+}
+
+func TestPriceStay(t *testing.T) {
+	store := repository.NewMemoryStore()
+	julyStart, julyEnd := day(t, "2026-07-01"), day(t, "2026-07-31")
+	overrides := []models.RateOverride{
+		{RoomTypeID: 1, Weekdays: "fri,sat", Amount: 15000},
+		{RoomTypeID: 1, StartDate: &julyStart, EndDate: &julyEnd, Amount: 20000},
+		{RoomTypeID: 1, StartDate: &julyStart, EndDate: &julyEnd, Weekdays: "sat", Amount: 25000},
+		{RoomTypeID: 2, Amount: 90000},
+	}
+	for i := range overrides {
+		if err := store.Rates().CreateOverride(&overrides[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	roomType := &models.RoomType{ID: 1, Name: "double", BasePrice: 10000, Currency: "USD"}
+	standard := &models.RatePlan{Code: "standard"}
+
+	tests := []struct {
+		name       string
+		plan       *models.RatePlan
+		start, end string
+		want       []int64
+	}{
+		{
+			name:  "base price on weekdays",
+			plan:  standard,
+			start: "2026-01-05", end: "2026-01-07",
+			want: []int64{10000, 10000},
+		},
+		{
+			name:  "weekday override",
+			plan:  standard,
+			start: "2026-01-01", end: "2026-01-04",
+			want: []int64{10000, 15000, 15000},
+		},
+		{
+			name:  "season beats weekdays and season weekdays beat season",
+			plan:  standard,
+			start: "2026-07-02", end: "2026-07-05",
+			want: []int64{20000, 20000, 25000},
+		},
+		{
+			name:  "plan percentage and supplement",
+			plan:  &models.RatePlan{Code: "breakfast", PercentAdjustment: -10, NightlySupplement: 500},
+			start: "2026-01-01", end: "2026-01-03",
+			want: []int64{9500, 14000},
+		},
+		{
+			name:  "percentage rounded to the cent",
+			plan:  &models.RatePlan{Code: "odd", PercentAdjustment: 0.5},
+			start: "2026-01-05", end: "2026-01-06",
+			want: []int64{10050},
+		},
+		{
+			name:  "same-day stay is one night",
+			plan:  standard,
+			start: "2026-01-05", end: "2026-01-05",
+			want: []int64{10000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nights, err := PriceStay(store, roomType, tt.plan, day(t, tt.start), day(t, tt.end), 1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := amounts(nights); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceStayNewestOverrideWinsTies(t *testing.T) {
+	store := repository.NewMemoryStore()
+	for _, amount := range []int64{12000, 13000} {
+		if err := store.Rates().CreateOverride(&models.RateOverride{RoomTypeID: 1, Weekdays: "mon", Amount: amount}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	roomType := &models.RoomType{ID: 1, Name: "double", BasePrice: 10000, Currency: "USD"}
+
+	nights, err := PriceStay(store, roomType, &models.RatePlan{Code: "standard"}, day(t, "2026-01-05"), day(t, "2026-01-06"), 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := amounts(nights); !reflect.DeepEqual(got, []int64{13000}) {
+		t.Errorf("got %v, want [13000]", got)
+	}
+}
+
+func TestFindRatePlan(t *testing.T) {
+	store := repository.NewMemoryStore()
+
+	plan, err := FindRatePlan(store, "")
+	if err != nil || plan.Code != DefaultRatePlan {
+		t.Errorf("got %v, %v, want the %s plan", plan, err, DefaultRatePlan)
+	}
+	if _, err := FindRatePlan(store, "nope"); !errors.Is(err, ErrUnknownRatePlan) {
+		t.Errorf("got %v, want %v", err, ErrUnknownRatePlan)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"hotel_management_system/repository"
+	"sort"
+	"time"
+)
+
+// Revenue is an amount charged to guests in minor units of the base
+// currency, taxes included, and the share of it that is tax.
+type Revenue struct {
+	Gross int64
+	Tax   int64
+}
+
+// Net is the revenue the hotel keeps after taxes.
+func (r Revenue) Net() int64 {
+	return r.Gross - r.Tax
+}
+
+func (r Revenue) add(row repository.RevenueRow) Revenue {
+	return Revenue{Gross: r.Gross + row.Amount, Tax: r.Tax + row.Tax}
+}
+
+// DailyRevenue is the revenue of the nights sold on Date (UTC).
+type DailyRevenue struct {
+	Date    time.Time
+	Revenue Revenue
+}
+
+// MonthlyRevenue is the revenue of the nights sold in Month (UTC, formatted
+// as YYYY-MM).
+type MonthlyRevenue struct {
+	Month   string
+	Revenue Revenue
+}
+
+// revenueRows returns the revenue rows of the reservations fully contained
+// in [start, end] whose status is one of statuses, converted to the base
+// currency at the rates of the day each reservation was booked.
+func revenueRows(store repository.Store, start, end time.Time, statuses []string) ([]repository.RevenueRow, error) {
+	rows, err := store.Reservations().RevenueRows(start, end, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := newConverter(store)
+	base := BaseCurrency()
+	for i, row := range rows {
+		if rows[i].Amount, err = conv.convert(row.Amount, row.Currency, base, row.BookedAt); err != nil {
+			return nil, err
+		}
+		if rows[i].Tax, err = conv.convert(row.Tax, row.Currency, base, row.BookedAt); err != nil {
+			return nil, err
+		}
+		rows[i].Currency = base
+	}
+	return rows, nil
+}
+
+// TotalRevenue sums the revenue of the matching reservations in the base
+// currency.
+func TotalRevenue(store repository.Store, start, end time.Time, statuses []string) (Revenue, error) {
+	rows, err := revenueRows(store, start, end, statuses)
+	if err != nil {
+		return Revenue{}, err
+	}
+	var total Revenue
+	for _, row := range rows {
+		total = total.add(row)
+	}
+	return total, nil
+}
+
+// RevenueByDay groups the revenue of the matching reservations by the UTC
+// calendar day of the night.
+func RevenueByDay(store repository.Store, start, end time.Time, statuses []string) ([]DailyRevenue, error) {
+	rows, err := revenueRows(store, start, end, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[time.Time]Revenue)
+	for _, row := range rows {
+		day := dayOf(row.Date)
+		byDate[day] = byDate[day].add(row)
+	}
+
+	revenues := make([]DailyRevenue, 0, len(byDate))
+	for date, revenue := range byDate {
+		revenues = append(revenues, DailyRevenue{Date: date, Revenue: revenue})
+	}
+	sort.Slice(revenues, func(i, j int) bool { return revenues[i].Date.Before(revenues[j].Date) })
+	return revenues, nil
+}
+
+// RevenueByMonth groups the revenue of the matching reservations by the
+// UTC month of the night.
+func RevenueByMonth(store repository.Store, start, end time.Time, statuses []string) ([]MonthlyRevenue, error) {
+	rows, err := revenueRows(store, start, end, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	byMonth := make(map[string]Revenue)
+	for _, row := range rows {
+		month := row.Date.UTC().Format("2006-01")
+		byMonth[month] = byMonth[month].add(row)
+	}
+
+	revenues := make([]MonthlyRevenue, 0, len(byMonth))
+	for month, revenue := range byMonth {
+		revenues = append(revenues, MonthlyRevenue{Month: month, Revenue: revenue})
+	}
+	sort.Slice(revenues, func(i, j int) bool { return revenues[i].Month < revenues[j].Month })
+	return revenues, nil
+}
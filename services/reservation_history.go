@@ -0,0 +1,61 @@
+package service
+
+import (
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"strconv"
+	"time"
+)
+
+// reservationFields returns the audited fields of a reservation formatted
+// for the history table, in a stable order.
+func reservationFields(reservation *models.Reservation) [][2]string {
+	return [][2]string{
+		{"status", reservation.Status},
+		{"user_id", strconv.FormatUint(uint64(reservation.UserID), 10)},
+		{"room_id", strconv.FormatUint(uint64(reservation.RoomID), 10)},
+		{"start_date", reservation.StartDate.UTC().Format(time.RFC3339)},
+		{"end_date", reservation.EndDate.UTC().Format(time.RFC3339)},
+		{"adults", strconv.Itoa(reservation.Adults)},
+		{"children", strconv.Itoa(reservation.Children)},
+		{"preferences", reservation.Preferences},
+		{"rate_plan_id", strconv.FormatUint(uint64(reservation.RatePlanID), 10)},
+		{"total_amount", strconv.FormatInt(reservation.TotalAmount, 10)},
+	}
+}
+
+// recordChanges writes one history event per audited field that differs
+// between before and after. A nil before records the creation of the
+// reservation as a change of its status from nothing.
+func recordChanges(store repository.Store, before, after *models.Reservation, actorID uint) error {
+	now := time.Now()
+	newValues := reservationFields(after)
+
+	if before == nil {
+		return store.ReservationEvents().Create(&models.ReservationEvent{
+			ReservationID: after.ID,
+			UserID:        actorID,
+			Field:         "status",
+			NewValue:      after.Status,
+			CreatedAt:     now,
+		})
+	}
+
+	for i, old := range reservationFields(before) {
+		if old[1] == newValues[i][1] {
+			continue
+		}
+		event := models.ReservationEvent{
+			ReservationID: after.ID,
+			UserID:        actorID,
+			Field:         old[0],
+			OldValue:      old[1],
+			NewValue:      newValues[i][1],
+			CreatedAt:     now,
+		}
+		if err := store.ReservationEvents().Create(&event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"time"
+)
+
+var (
+	ErrNotEditable  = errors.New("only upcoming and checked-in reservations can be changed")
+	ErrArrivalFixed = errors.New("the arrival of a checked-in reservation cannot change")
+)
+
+// ReservationPatch holds the fields of a reservation a partial update may
+// change. Nil fields are left as they are.
+type ReservationPatch struct {
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	Adults      *int       `json:"adults"`
+	Children    *int       `json:"children"`
+	Preferences *string    `json:"preferences"`
+}
+
+// PatchReservation applies patch to the reservation with the given ID on
+// behalf of actorID, provided it is still at version. Only upcoming and
+// checked-in stays can change, and a checked-in guest keeps the arrival.
+// The result is saved through SaveReservation, so new dates or guests are
+// checked against the availability of the room and its type and priced
+// again.
+func PatchReservation(store repository.Store, id, version uint, patch ReservationPatch, actorID uint) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := store.Transaction(func(tx repository.Store) error {
+		var err error
+		reservation, err = tx.Reservations().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if reservation.Version != version {
+			return repository.ErrVersionConflict
+		}
+		if !IsBlocking(reservation.Status) {
+			return ErrNotEditable
+		}
+
+		if patch.StartDate != nil {
+			if reservation.Status == "checked-in" && !patch.StartDate.Equal(reservation.StartDate) {
+				return ErrArrivalFixed
+			}
+			reservation.StartDate = *patch.StartDate
+		}
+		if patch.EndDate != nil {
+			reservation.EndDate = *patch.EndDate
+		}
+		if patch.Adults != nil {
+			reservation.Adults = *patch.Adults
+		}
+		if patch.Children != nil {
+			reservation.Children = *patch.Children
+		}
+		if patch.Preferences != nil {
+			if reservation.Preferences, err = NormalizePreferences(*patch.Preferences); err != nil {
+				return err
+			}
+		}
+		reservation.UpdatedAt = time.Now()
+		return SaveReservation(tx, reservation, actorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
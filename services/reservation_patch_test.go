@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/repository"
+	"testing"
+	"time"
+)
+
+func TestPatchReservation(t *testing.T) {
+	store := newTestStore(t)
+	reservation := book(t, store, 1, "2026-01-05", "2026-01-07")
+	book(t, store, 1, "2026-01-08", "2026-01-10")
+	cancelled := book(t, store, 2, "2026-01-05", "2026-01-07")
+	cancelled.Status = "cancelled"
+	if err := SaveReservation(store, cancelled, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	departure := day(t, "2026-01-08")
+	overlap := day(t, "2026-01-09")
+	two, three := 2, 3
+	preferences, bad := " Accessible ", "sea-view"
+
+	// The cases run in order against the same reservation.
+	tests := []struct {
+		name    string
+		id      uint
+		version uint
+		patch   ReservationPatch
+		err     error
+		total   int64
+	}{
+		{name: "stale version", id: reservation.ID, version: 7, patch: ReservationPatch{Adults: &two}, err: repository.ErrVersionConflict},
+		{name: "cancelled stay", id: cancelled.ID, version: cancelled.Version, patch: ReservationPatch{Adults: &two}, err: ErrNotEditable},
+		{name: "longer stay", id: reservation.ID, version: 1, patch: ReservationPatch{EndDate: &departure}, total: 30000},
+		{name: "overlapping the next stay", id: reservation.ID, version: 2, patch: ReservationPatch{EndDate: &overlap}, err: ErrRoomUnavailable},
+		{name: "too many guests", id: reservation.ID, version: 2, patch: ReservationPatch{Adults: &three}, err: ErrOverOccupancy},
+		{name: "unknown preference", id: reservation.ID, version: 2, patch: ReservationPatch{Preferences: &bad}, err: ErrInvalidPreferences},
+		{name: "party and preferences", id: reservation.ID, version: 2, patch: ReservationPatch{Adults: &two, Preferences: &preferences}, total: 30000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, err := PatchReservation(store, tt.id, tt.version, tt.patch, 1)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if patched.TotalAmount != tt.total || patched.Version != tt.version+1 {
+				t.Errorf("got total %d at version %d, want %d at %d", patched.TotalAmount, patched.Version, tt.total, tt.version+1)
+			}
+		})
+	}
+
+	stored, err := store.Reservations().FindByID(reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Version != 3 || !stored.EndDate.Equal(departure) || stored.Adults != 2 || stored.Preferences != "accessible" {
+		t.Errorf("stored version %d ending %s with %d adults preferring %q, want the two accepted patches only",
+			stored.Version, stored.EndDate, stored.Adults, stored.Preferences)
+	}
+}
+
+func TestPatchCheckedInReservation(t *testing.T) {
+	store := newTestStore(t)
+	reservation := checkInSinceYesterday(t, store, 1)
+
+	arrival := reservation.StartDate.AddDate(0, 0, -1)
+	if _, err := PatchReservation(store, reservation.ID, reservation.Version, ReservationPatch{StartDate: &arrival}, 1); !errors.Is(err, ErrArrivalFixed) {
+		t.Errorf("moving the arrival: got %v, want %v", err, ErrArrivalFixed)
+	}
+
+	same, departure := reservation.StartDate, reservation.EndDate.Add(24*time.Hour)
+	patched, err := PatchReservation(store, reservation.ID, reservation.Version, ReservationPatch{StartDate: &same, EndDate: &departure}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patched.Nights) != 4 || patched.TotalAmount != 40000 {
+		t.Errorf("got %d nights at %d, want 4 at 40000", len(patched.Nights), patched.TotalAmount)
+	}
+
+	// The folio was charged the three booked nights at check-in; the fourth
+	// is added to it.
+	folio, err := GetFolio(store, reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folio.Balance != 40000 || len(folio.Lines) != 2 || folio.Lines[1].Description != "Room charge adjustment" {
+		t.Errorf("got balance %d over %d lines, want 40000 with the adjustment last", folio.Balance, len(folio.Lines))
+	}
+}
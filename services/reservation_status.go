@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"time"
+)
+
+var (
+	ErrInvalidStatus     = errors.New("invalid reservation status")
+	ErrInvalidTransition = errors.New("reservation status transition not allowed")
+)
+
+// statusTransitions lists, for every reservation status, the statuses it may
+// move to. Checked-out, cancelled and no-show are final.
+var statusTransitions = map[string][]string{
+	"pending":     {"confirmed", "cancelled", "no-show"},
+	"confirmed":   {"checked-in", "cancelled", "no-show"},
+	"checked-in":  {"checked-out"},
+	"checked-out": {},
+	"cancelled":   {},
+	"no-show":     {},
+}
+
+// CanTransition reports whether a reservation may move from one status to
+// another.
+func CanTransition(from, to string) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns ErrInvalidStatus for an unknown target status
+// and ErrInvalidTransition when the state machine forbids the move.
+func ValidateTransition(from, to string) error {
+	if _, ok := statusTransitions[to]; !ok {
+		return ErrInvalidStatus
+	}
+	if !CanTransition(from, to) {
+		return ErrInvalidTransition
+	}
+	return nil
+}
+
+// StampStatus records who moved the reservation into its current status.
+func StampStatus(reservation *models.Reservation, actorID uint) {
+	now := time.Now()
+	reservation.StatusUpdatedBy = actorID
+	reservation.StatusUpdatedAt = &now
+	reservation.UpdatedAt = now
+}
+
+// TransitionReservation moves a reservation at version to status on behalf
+// of actorID. The reservation row is locked for the duration so that
+// concurrent transitions are validated against each other's result, and a
+// reservation that moved on from version fails with
+// repository.ErrVersionConflict. Check-in and check-out go through CheckIn
+// and CheckOut so that the room follows, and cancellation through
+// CancelReservation so that the penalty is posted; the folio balance is
+// never overridden here.
+func TransitionReservation(store repository.Store, id, version uint, status string, actorID uint) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := store.Transaction(func(tx repository.Store) error {
+		var err error
+		reservation, err = tx.Reservations().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if reservation.Version != version {
+			return repository.ErrVersionConflict
+		}
+
+		switch status {
+		case "checked-in":
+			reservation, err = CheckIn(tx, id, actorID)
+			return err
+		case "checked-out":
+			reservation, err = CheckOut(tx, id, actorID, false)
+			return err
+		case "cancelled":
+			reservation, _, err = CancelReservation(tx, id, actorID)
+			return err
+		}
+
+		if err := ValidateTransition(reservation.Status, status); err != nil {
+			return err
+		}
+		reservation.Status = status
+		StampStatus(reservation, actorID)
+		return SaveReservation(tx, reservation, actorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
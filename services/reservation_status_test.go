@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/repository"
+	"testing"
+)
+
+// TestTransitionReservation moves a stay through its statuses. The cases run
+// in order, so each names the version the previous transition left.
+func TestTransitionReservation(t *testing.T) {
+	store := newTestStore(t)
+	reservation := book(t, store, 1, "2026-01-05", "2026-01-07")
+
+	tests := []struct {
+		name    string
+		version uint
+		status  string
+		err     error
+	}{
+		{name: "stale version", version: 7, status: "confirmed", err: repository.ErrVersionConflict},
+		{name: "not allowed", version: 1, status: "checked-out", err: ErrInvalidTransition},
+		{name: "unknown status", version: 1, status: "lost", err: ErrInvalidStatus},
+		{name: "confirm", version: 1, status: "confirmed"},
+		{name: "cancel at the version before", version: 1, status: "cancelled", err: repository.ErrVersionConflict},
+		{name: "cancel", version: 2, status: "cancelled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moved, err := TransitionReservation(store, reservation.ID, tt.version, tt.status, 1)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if err == nil && (moved.Status != tt.status || moved.Version != tt.version+1) {
+				t.Errorf("got %s at version %d, want %s at %d", moved.Status, moved.Version, tt.status, tt.version+1)
+			}
+		})
+	}
+}
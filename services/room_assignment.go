@@ -0,0 +1,195 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownStrategy    = errors.New("unknown room assignment strategy")
+	ErrInvalidPreferences = errors.New("invalid room preferences")
+)
+
+// DefaultAssignmentStrategy is the strategy used when
+// ROOM_ASSIGNMENT_STRATEGY is not set.
+const DefaultAssignmentStrategy = "preferences"
+
+// wearWindow is how far back BalanceWearStrategy counts the nights sold.
+const wearWindow = 90 * 24 * time.Hour
+
+// RoomAssignmentStrategy picks the room a booking by room type gets.
+type RoomAssignmentStrategy interface {
+	// Rank orders the free rooms of the type of reservation from best to
+	// worst.
+	Rank(store repository.Store, reservation *models.Reservation, rooms []models.Room) ([]models.Room, error)
+}
+
+// LowestFloorStrategy fills the lowest floors first so that upper floors
+// can be closed when the hotel is quiet.
+type LowestFloorStrategy struct{}
+
+func (LowestFloorStrategy) Rank(store repository.Store, reservation *models.Reservation, rooms []models.Room) ([]models.Room, error) {
+	ranked := append([]models.Room(nil), rooms...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Floor != ranked[j].Floor {
+			return ranked[i].Floor < ranked[j].Floor
+		}
+		return ranked[i].Number < ranked[j].Number
+	})
+	return ranked, nil
+}
+
+// BalanceWearStrategy gives out the rooms that sold the fewest nights
+// recently first, spreading wear evenly over the rooms of a type.
+type BalanceWearStrategy struct{}
+
+func (BalanceWearStrategy) Rank(store repository.Store, reservation *models.Reservation, rooms []models.Room) ([]models.Room, error) {
+	end := time.Now()
+	reservations, err := store.Reservations().FindOverlapping(end.Add(-wearWindow), end, []string{"confirmed", "checked-in", "checked-out"})
+	if err != nil {
+		return nil, err
+	}
+	nights := make(map[uint]int)
+	for _, other := range reservations {
+		for _, stay := range roomStays(&other) {
+			if stay.EndDate.After(end.Add(-wearWindow)) && stay.StartDate.Before(end) {
+				nights[stay.RoomID] += Nights(maxTime(stay.StartDate, end.Add(-wearWindow)), minTime(stay.EndDate, end))
+			}
+		}
+	}
+
+	ranked := append([]models.Room(nil), rooms...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if nights[ranked[i].ID] != nights[ranked[j].ID] {
+			return nights[ranked[i].ID] < nights[ranked[j].ID]
+		}
+		return ranked[i].Number < ranked[j].Number
+	})
+	return ranked, nil
+}
+
+// PreferenceStrategy puts the rooms matching most of the preferences of the
+// guest first and leaves the order among equally good rooms to Fallback.
+// High and low floor mean the highest and lowest floor among the rooms
+// ranked.
+type PreferenceStrategy struct {
+	Fallback RoomAssignmentStrategy
+}
+
+func (s PreferenceStrategy) Rank(store repository.Store, reservation *models.Reservation, rooms []models.Room) ([]models.Room, error) {
+	ranked, err := s.Fallback.Rank(store, reservation, rooms)
+	if err != nil {
+		return nil, err
+	}
+
+	score := preferenceScores(ParsePreferences(reservation.Preferences), rooms)
+	sort.SliceStable(ranked, func(i, j int) bool { return score[ranked[i].ID] > score[ranked[j].ID] })
+	return ranked, nil
+}
+
+// preferenceScores counts the preferences each of rooms matches. High and
+// low floor are relative to the floors of rooms.
+func preferenceScores(preferences []string, rooms []models.Room) map[uint]int {
+	lowest, highest := 0, 0
+	for i, room := range rooms {
+		if i == 0 || room.Floor < lowest {
+			lowest = room.Floor
+		}
+		if i == 0 || room.Floor > highest {
+			highest = room.Floor
+		}
+	}
+
+	scores := make(map[uint]int, len(rooms))
+	for _, room := range rooms {
+		for _, preference := range preferences {
+			switch {
+			case preference == "accessible" && room.Accessible,
+				preference == "high-floor" && room.Floor == highest,
+				preference == "low-floor" && room.Floor == lowest:
+				scores[room.ID]++
+			}
+		}
+	}
+	return scores
+}
+
+// assignmentStrategies are the strategies ROOM_ASSIGNMENT_STRATEGY can name.
+var assignmentStrategies = map[string]RoomAssignmentStrategy{
+	"lowest-floor": LowestFloorStrategy{},
+	"balance-wear": BalanceWearStrategy{},
+	"preferences":  PreferenceStrategy{Fallback: LowestFloorStrategy{}},
+}
+
+// AssignmentStrategy returns the strategy registered under name.
+func AssignmentStrategy(name string) (RoomAssignmentStrategy, error) {
+	strategy, ok := assignmentStrategies[name]
+	if !ok {
+		return nil, ErrUnknownStrategy
+	}
+	return strategy, nil
+}
+
+// ConfiguredAssignmentStrategy returns the strategy named by the
+// ROOM_ASSIGNMENT_STRATEGY environment variable, DefaultAssignmentStrategy
+// when it is not set.
+func ConfiguredAssignmentStrategy() (RoomAssignmentStrategy, error) {
+	name := os.Getenv("ROOM_ASSIGNMENT_STRATEGY")
+	if name == "" {
+		name = DefaultAssignmentStrategy
+	}
+	return AssignmentStrategy(name)
+}
+
+var roomPreferences = map[string]bool{
+	"high-floor": true,
+	"low-floor":  true,
+	"accessible": true,
+}
+
+// ParsePreferences splits a comma separated preference list.
+func ParsePreferences(preferences string) []string {
+	var parsed []string
+	for _, preference := range strings.Split(preferences, ",") {
+		if preference = strings.TrimSpace(strings.ToLower(preference)); preference != "" {
+			parsed = append(parsed, preference)
+		}
+	}
+	return parsed
+}
+
+// NormalizePreferences checks a comma separated preference list and returns
+// it in canonical form. High and low floor exclude each other.
+func NormalizePreferences(preferences string) (string, error) {
+	parsed := ParsePreferences(preferences)
+	seen := make(map[string]bool)
+	for _, preference := range parsed {
+		if !roomPreferences[preference] {
+			return "", ErrInvalidPreferences
+		}
+		seen[preference] = true
+	}
+	if seen["high-floor"] && seen["low-floor"] {
+		return "", ErrInvalidPreferences
+	}
+	return strings.Join(parsed, ","), nil
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"testing"
+	"time"
+)
+
+// newFloorStore returns a store made by newTestStore with rooms 101 and 102
+// on the first floor and an accessible room 201 of the same type on the
+// second.
+func newFloorStore(t *testing.T) repository.Store {
+	t.Helper()
+	store := newTestStore(t)
+	for _, id := range []uint{1, 2} {
+		room, err := store.Rooms().FindByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		room.Floor = 1
+		if err := store.Rooms().Save(room); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Rooms().Create(&models.Room{Number: "201", RoomTypeID: 1, Status: "available", Floor: 2, Accessible: true}); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// rank runs strategy over every room of store for a reservation with the
+// given preferences and returns the room numbers in order.
+func rank(t *testing.T, store repository.Store, strategy RoomAssignmentStrategy, preferences string) string {
+	t.Helper()
+	rooms, err := store.Rooms().FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Start from the worst order so that the ranking has to do the work.
+	for i, j := 0, len(rooms)-1; i < j; i, j = i+1, j-1 {
+		rooms[i], rooms[j] = rooms[j], rooms[i]
+	}
+	ranked, err := strategy.Rank(store, &models.Reservation{Preferences: preferences}, rooms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	numbers := make([]string, 0, len(ranked))
+	for _, room := range ranked {
+		numbers = append(numbers, room.Number)
+	}
+	return fmt.Sprint(numbers)
+}
+
+func TestLowestFloorStrategy(t *testing.T) {
+	store := newFloorStore(t)
+	if got := rank(t, store, LowestFloorStrategy{}, ""); got != "[101 102 201]" {
+		t.Errorf("got %s, want [101 102 201]", got)
+	}
+}
+
+func TestBalanceWearStrategy(t *testing.T) {
+	store := newFloorStore(t)
+	today := dayOf(time.Now())
+	for _, stay := range []struct {
+		roomID uint
+		nights int
+	}{{1, 3}, {2, 1}} {
+		reservation := bookStay(t, store, stay.roomID, today.AddDate(0, 0, -10), today.AddDate(0, 0, -10+stay.nights))
+		reservation.Status = "checked-out"
+		if err := store.Reservations().Save(reservation); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := rank(t, store, BalanceWearStrategy{}, ""); got != "[201 102 101]" {
+		t.Errorf("got %s, want the least sold room first: [201 102 101]", got)
+	}
+}
+
+func TestPreferenceStrategy(t *testing.T) {
+	store := newFloorStore(t)
+	strategy := PreferenceStrategy{Fallback: LowestFloorStrategy{}}
+	tests := []struct {
+		preferences string
+		want        string
+	}{
+		{preferences: "", want: "[101 102 201]"},
+		{preferences: "high-floor", want: "[201 101 102]"},
+		{preferences: "low-floor", want: "[101 102 201]"},
+		{preferences: "accessible", want: "[201 101 102]"},
+		{preferences: "accessible,low-floor", want: "[101 102 201]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preferences, func(t *testing.T) {
+			if got := rank(t, store, strategy, tt.preferences); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePreferences(t *testing.T) {
+	tests := []struct {
+		preferences string
+		want        string
+		err         error
+	}{
+		{preferences: "", want: ""},
+		{preferences: " High-Floor , accessible", want: "high-floor,accessible"},
+		{preferences: "sea-view", err: ErrInvalidPreferences},
+		{preferences: "high-floor,low-floor", err: ErrInvalidPreferences},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preferences, func(t *testing.T) {
+			got, err := NormalizePreferences(tt.preferences)
+			if !errors.Is(err, tt.err) || got != tt.want {
+				t.Errorf("got %q, %v, want %q, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestAssignmentStrategy(t *testing.T) {
+	if _, err := AssignmentStrategy("balance-wear"); err != nil {
+		t.Error(err)
+	}
+	if _, err := AssignmentStrategy("random"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Errorf("got %v, want %v", err, ErrUnknownStrategy)
+	}
+}
+
+func TestBookRoomTypeByPreference(t *testing.T) {
+	store := newFloorStore(t)
+	reservation := &models.Reservation{UserID: 1, RoomTypeID: 1, StartDate: day(t, "2026-01-05"), EndDate: day(t, "2026-01-07"), Status: "pending", Adults: 1, Preferences: "high-floor"}
+	plan, err := FindRatePlan(store, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := PriceReservation(store, reservation, plan); err != nil {
+		t.Fatal(err)
+	}
+	if err := BookRoomType(store, PreferenceStrategy{Fallback: LowestFloorStrategy{}}, reservation, 1); err != nil {
+		t.Fatal(err)
+	}
+	if reservation.RoomID != 3 || !reservation.AutoAssigned {
+		t.Errorf("got room %d, auto-assigned %v, want room 201 auto-assigned", reservation.RoomID, reservation.AutoAssigned)
+	}
+}
+
+// TestOptimizeRoomAssignments repacks a schedule. Room 101 holds a stay
+// booked by number that ends on day 3 and 102 a stay arriving today; both
+// stay put. The auto-assigned stay from day 3 moves next to the first one
+// in 101, and the one from day 6 moves to 201, the only high floor room.
+func TestOptimizeRoomAssignments(t *testing.T) {
+	store := newFloorStore(t)
+	today := dayOf(time.Now())
+	stay := func(roomID uint, from, to int, autoAssigned bool, preferences string) *models.Reservation {
+		t.Helper()
+		reservation := bookStay(t, store, roomID, today.AddDate(0, 0, from), today.AddDate(0, 0, to))
+		reservation.AutoAssigned = autoAssigned
+		reservation.Preferences = preferences
+		if err := store.Reservations().Save(reservation); err != nil {
+			t.Fatal(err)
+		}
+		return reservation
+	}
+	stay(1, 1, 3, false, "")
+	stay(2, 0, 2, true, "")
+	next := stay(3, 3, 5, true, "")
+	highFloor := stay(1, 6, 8, true, "high-floor")
+
+	moves, err := OptimizeRoomAssignments(store, LowestFloorStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RoomMove{
+		{ReservationID: next.ID, FromRoomID: 3, ToRoomID: 1},
+		{ReservationID: highFloor.ID, FromRoomID: 1, ToRoomID: 3},
+	}
+	if fmt.Sprint(moves) != fmt.Sprint(want) {
+		t.Fatalf("got moves %v, want %v", moves, want)
+	}
+
+	moved, err := store.Reservations().FindByID(next.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.RoomID != 1 || moved.TotalAmount != next.TotalAmount || !moved.AutoAssigned {
+		t.Errorf("got room %d at %d, want room 101 at the booked %d", moved.RoomID, moved.TotalAmount, next.TotalAmount)
+	}
+	events, err := store.ReservationEvents().FindByReservation(next.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := events[len(events)-1]
+	if last.Field != "room_id" || last.OldValue != "3" || last.NewValue != "1" || last.UserID != 0 {
+		t.Errorf("got last event %+v, want a room change by the system", last)
+	}
+	if moved.Version != next.Version+1 {
+		t.Errorf("moved at version %d, want %d", moved.Version, next.Version+1)
+	}
+
+	// A second run finds nothing left to improve.
+	if moves, err := OptimizeRoomAssignments(store, LowestFloorStrategy{}); err != nil || len(moves) != 0 {
+		t.Errorf("second run: got %v, %v, want no moves", moves, err)
+	}
+}
+
+// TestOptimizeRoomAssignmentsByBookedType turns room 201 into a suite after
+// a double was assigned to it: the stay goes back to a double.
+func TestOptimizeRoomAssignmentsByBookedType(t *testing.T) {
+	store := newFloorStore(t)
+	suite := addSuite(t, store)
+	today := dayOf(time.Now())
+	reservation := bookStay(t, store, 3, today.AddDate(0, 0, 3), today.AddDate(0, 0, 5))
+	reservation.AutoAssigned = true
+	if err := store.Reservations().Save(reservation); err != nil {
+		t.Fatal(err)
+	}
+	room, err := store.Rooms().FindByID(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	room.RoomTypeID = suite.ID
+	if err := store.Rooms().Save(room); err != nil {
+		t.Fatal(err)
+	}
+
+	moves, err := OptimizeRoomAssignments(store, LowestFloorStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []RoomMove{{ReservationID: reservation.ID, FromRoomID: 3, ToRoomID: 1}}; fmt.Sprint(moves) != fmt.Sprint(want) {
+		t.Errorf("got moves %v, want %v", moves, want)
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"time"
+)
+
+var (
+	ErrMoveNotAllowed = errors.New("only upcoming and checked-in reservations can move rooms before departure")
+	ErrSameRoom       = errors.New("reservation is already in that room")
+	ErrStaySplit      = errors.New("the room of a stay split between rooms can only change by moving it")
+)
+
+// MoveReservation moves a reservation to another room on behalf of actorID.
+// An upcoming stay moves as a whole. A checked-in guest moves now: the stay
+// is split into segments, the room left goes to cleaning with a
+// housekeeping task and the new room, which has to be ready, becomes
+// occupied. The new room must be in service, free for the rest of the stay
+// and fit the party. The stay keeps the room type it was sold as and its
+// price, and each night is attributed to the room it was spent in.
+func MoveReservation(store repository.Store, id, roomID uint, actorID uint) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := store.Transaction(func(tx repository.Store) error {
+		var err error
+		reservation, err = tx.Reservations().FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		before := *reservation
+
+		now := time.Now()
+		if !IsBlocking(reservation.Status) || !reservation.EndDate.After(now) {
+			return ErrMoveNotAllowed
+		}
+		if roomID == reservation.RoomID {
+			return ErrSameRoom
+		}
+
+		room, err := lockRoom(tx, roomID)
+		if err != nil {
+			return err
+		}
+		if room.Status == models.RoomOutOfService {
+			return ErrRoomOutOfService
+		}
+		roomType, err := FindRoomType(tx, room.RoomTypeID)
+		if err != nil {
+			return err
+		}
+		if err := ValidateGuests(roomType, reservation); err != nil {
+			return err
+		}
+
+		split := reservation.Status == "checked-in" && now.After(reservation.StartDate)
+		from := reservation.StartDate
+		if split {
+			from = now
+		}
+		if err := CheckAvailability(tx, roomID, from, reservation.EndDate, reservation.ID); err != nil {
+			return err
+		}
+
+		if reservation.Status == "checked-in" {
+			if room.Status != "available" {
+				return ErrRoomNotReady
+			}
+			left, err := lockRoom(tx, reservation.RoomID)
+			if err != nil {
+				return err
+			}
+			left.Status = "cleaning"
+			left.UpdateAt = now
+			if err := tx.Rooms().Save(left); err != nil {
+				return err
+			}
+			if err := createCleaningTask(tx, left.ID, reservation.ID); err != nil {
+				return err
+			}
+			room.Status = "occupied"
+			room.UpdateAt = now
+			if err := tx.Rooms().Save(room); err != nil {
+				return err
+			}
+		}
+
+		if split {
+			var segments []models.ReservationSegment
+			for _, stay := range roomStays(reservation) {
+				if stay.StartDate.Before(now) {
+					stay.EndDate = minTime(stay.EndDate, now)
+					segments = append(segments, stay)
+				}
+			}
+			reservation.Segments = append(segments, models.ReservationSegment{RoomID: roomID, StartDate: now, EndDate: reservation.EndDate})
+			if err := tx.Reservations().ReplaceSegments(reservation.ID, reservation.Segments); err != nil {
+				return err
+			}
+		}
+
+		reservation.RoomID = roomID
+		reservation.AutoAssigned = false
+		reservation.UpdatedAt = now
+		if err := tx.Reservations().Save(reservation); err != nil {
+			return err
+		}
+		if attributeNights(reservation) {
+			if err := tx.Reservations().ReplacePricing(reservation.ID, reservation.Nights, reservation.Taxes); err != nil {
+				return err
+			}
+		}
+		return recordChanges(tx, &before, reservation, actorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// roomStays returns the parts of a stay spent in each room: the segments of
+// the reservation, or the whole stay in its room when the guest never moved.
+func roomStays(reservation *models.Reservation) []models.ReservationSegment {
+	if len(reservation.Segments) > 0 {
+		return reservation.Segments
+	}
+	return []models.ReservationSegment{{
+		ReservationID: reservation.ID,
+		RoomID:        reservation.RoomID,
+		StartDate:     reservation.StartDate,
+		EndDate:       reservation.EndDate,
+	}}
+}
+
+// attributeNights sets the room of each night to the room of the last
+// segment starting on or before its date and reports whether any changed.
+func attributeNights(reservation *models.Reservation) bool {
+	stays := roomStays(reservation)
+	changed := false
+	for i := range reservation.Nights {
+		roomID := stays[0].RoomID
+		for _, stay := range stays[1:] {
+			if !dayOf(stay.StartDate).After(reservation.Nights[i].Date) {
+				roomID = stay.RoomID
+			}
+		}
+		if reservation.Nights[i].RoomID != roomID {
+			reservation.Nights[i].RoomID = roomID
+			changed = true
+		}
+	}
+	return changed
+}
+
+// resizeSegments fits the segments of a split stay to new dates. Segments
+// outside them are dropped and the first and last left are stretched to the
+// new arrival and departure; a stay moved past all of them is spent in the
+// last room.
+func resizeSegments(segments []models.ReservationSegment, start, end time.Time) []models.ReservationSegment {
+	var resized []models.ReservationSegment
+	for _, segment := range segments {
+		if segment.StartDate.Before(end) && segment.EndDate.After(start) {
+			resized = append(resized, segment)
+		}
+	}
+	if len(resized) == 0 {
+		resized = append(resized, segments[len(segments)-1])
+	}
+	resized[0].StartDate = start
+	resized[len(resized)-1].EndDate = end
+	return resized
+}
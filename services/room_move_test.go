@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"testing"
+	"time"
+)
+
+// nightRooms lists the room each night of a reservation is attributed to.
+func nightRooms(reservation *models.Reservation) string {
+	rooms := make([]uint, 0, len(reservation.Nights))
+	for _, night := range reservation.Nights {
+		rooms = append(rooms, night.RoomID)
+	}
+	return fmt.Sprint(rooms)
+}
+
+// checkInSinceYesterday checks the guest in to roomID for a stay that began
+// yesterday and ends in two days.
+func checkInSinceYesterday(t *testing.T, store repository.Store, roomID uint) *models.Reservation {
+	t.Helper()
+	today := dayOf(time.Now())
+	reservation := bookStay(t, store, roomID, today.AddDate(0, 0, -1), today.AddDate(0, 0, 2))
+	reservation.Status = "confirmed"
+	if err := SaveReservation(store, reservation, 1); err != nil {
+		t.Fatal(err)
+	}
+	reservation, err := CheckIn(store, reservation.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reservation
+}
+
+func TestMoveUpcomingReservation(t *testing.T) {
+	store := newTestStore(t)
+	today := dayOf(time.Now())
+	reservation := bookStay(t, store, 1, today.AddDate(0, 0, 3), today.AddDate(0, 0, 5))
+
+	moved, err := MoveReservation(store, reservation.ID, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.RoomID != 2 || len(moved.Segments) != 0 || moved.TotalAmount != reservation.TotalAmount {
+		t.Errorf("got room %d, %d segments, total %d, want room 102 whole at %d",
+			moved.RoomID, len(moved.Segments), moved.TotalAmount, reservation.TotalAmount)
+	}
+	stored, err := store.Reservations().FindByID(reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := nightRooms(stored); got != "[2 2]" {
+		t.Errorf("got nights in rooms %s, want [2 2]", got)
+	}
+	if room, _ := store.Rooms().FindByID(1); room.Status != "available" {
+		t.Errorf("room left is %s, want it untouched before arrival", room.Status)
+	}
+}
+
+// TestMoveCheckedInSplitsStay moves a guest on the second night. The first
+// night stays with room 101, which goes to cleaning; the rest of the stay
+// is spent in 102.
+func TestMoveCheckedInSplitsStay(t *testing.T) {
+	store := newTestStore(t)
+	reservation := checkInSinceYesterday(t, store, 1)
+
+	moved, err := MoveReservation(store, reservation.ID, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moved.Segments) != 2 || moved.Segments[0].RoomID != 1 || moved.Segments[1].RoomID != 2 || moved.RoomID != 2 {
+		t.Fatalf("got segments %+v in room %d, want 101 then 102", moved.Segments, moved.RoomID)
+	}
+	if !moved.Segments[0].StartDate.Equal(reservation.StartDate) || !moved.Segments[1].EndDate.Equal(reservation.EndDate) ||
+		!moved.Segments[0].EndDate.Equal(moved.Segments[1].StartDate) {
+		t.Errorf("segments %+v do not cover the stay", moved.Segments)
+	}
+
+	stored, err := store.Reservations().FindByID(reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := nightRooms(stored); got != "[1 2 2]" {
+		t.Errorf("got nights in rooms %s, want [1 2 2]", got)
+	}
+	if stored.TotalAmount != reservation.TotalAmount {
+		t.Errorf("got total %d, want the booked %d", stored.TotalAmount, reservation.TotalAmount)
+	}
+	for id, want := range map[uint]string{1: "cleaning", 2: "occupied"} {
+		if room, _ := store.Rooms().FindByID(id); room.Status != want {
+			t.Errorf("room %d is %s, want %s", id, room.Status, want)
+		}
+	}
+	if tasks, _ := store.HousekeepingTasks().FindAll(""); len(tasks) != 1 || tasks[0].RoomID != 1 {
+		t.Errorf("got tasks %+v, want one for room 101", tasks)
+	}
+
+	// Room 101 is free again for the nights the guest no longer spends in it.
+	today := dayOf(time.Now())
+	if err := CheckAvailability(store, 1, today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), 0); err != nil {
+		t.Errorf("room 101 after the move: %v", err)
+	}
+	if err := CheckAvailability(store, 2, today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), 0); !errors.Is(err, ErrRoomUnavailable) {
+		t.Errorf("room 102 after the move: got %v, want %v", err, ErrRoomUnavailable)
+	}
+}
+
+func TestMoveReservationRefused(t *testing.T) {
+	store := newTestStore(t)
+	small := &models.RoomType{Name: "single", BasePrice: 8000, Currency: "USD", MaxOccupancy: 1}
+	if err := store.RoomTypes().Create(small); err != nil {
+		t.Fatal(err)
+	}
+	for _, room := range []models.Room{
+		{Number: "103", RoomTypeID: 1, Status: "cleaning"},
+		{Number: "104", RoomTypeID: 1, Status: models.RoomOutOfService},
+		{Number: "105", RoomTypeID: small.ID, Status: "available"},
+	} {
+		if err := store.Rooms().Create(&room); err != nil {
+			t.Fatal(err)
+		}
+	}
+	today := dayOf(time.Now())
+	couple := checkInSinceYesterday(t, store, 1)
+	couple.Adults = 2
+	if err := store.Reservations().Save(couple); err != nil {
+		t.Fatal(err)
+	}
+	bookStay(t, store, 2, today, today.AddDate(0, 0, 1))
+	cancelled := bookStay(t, store, 2, today.AddDate(0, 0, 5), today.AddDate(0, 0, 6))
+	cancelled.Status = "cancelled"
+	if err := store.Reservations().Save(cancelled); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		reservationID uint
+		roomID        uint
+		err           error
+	}{
+		{name: "same room", reservationID: couple.ID, roomID: 1, err: ErrSameRoom},
+		{name: "room taken", reservationID: couple.ID, roomID: 2, err: ErrRoomUnavailable},
+		{name: "room not ready", reservationID: couple.ID, roomID: 3, err: ErrRoomNotReady},
+		{name: "room out of service", reservationID: couple.ID, roomID: 4, err: ErrRoomOutOfService},
+		{name: "party does not fit", reservationID: couple.ID, roomID: 5, err: ErrOverOccupancy},
+		{name: "cancelled stay", reservationID: cancelled.ID, roomID: 1, err: ErrMoveNotAllowed},
+		{name: "unknown room", reservationID: couple.ID, roomID: 99, err: ErrRoomNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MoveReservation(store, tt.reservationID, tt.roomID, 1); !errors.Is(err, tt.err) {
+				t.Errorf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+	if room, _ := store.Rooms().FindByID(1); room.Status != "occupied" {
+		t.Errorf("room 101 is %s after refused moves, want occupied", room.Status)
+	}
+}
+
+// TestSaveSplitReservation changes a stay after it was split: its room can
+// only change by moving it, while new dates resize the segments.
+func TestSaveSplitReservation(t *testing.T) {
+	store := newTestStore(t)
+	reservation := checkInSinceYesterday(t, store, 1)
+	if _, err := MoveReservation(store, reservation.ID, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	update, err := store.Reservations().FindByID(reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update.RoomID = 1
+	if err := SaveReservation(store, update, 1); !errors.Is(err, ErrStaySplit) {
+		t.Fatalf("changing the room: got %v, want %v", err, ErrStaySplit)
+	}
+
+	update, err = store.Reservations().FindByID(reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update.EndDate = update.EndDate.AddDate(0, 0, 1)
+	if err := SaveReservation(store, update, 1); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := store.Reservations().FindByID(reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Segments) != 2 || !stored.Segments[1].EndDate.Equal(update.EndDate) || stored.RoomID != 2 {
+		t.Errorf("got segments %+v in room %d, want the stay in 102 extended", stored.Segments, stored.RoomID)
+	}
+	if got := nightRooms(stored); got != "[1 2 2 2]" {
+		t.Errorf("got nights in rooms %s, want [1 2 2 2]", got)
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"math"
+	"sort"
+	"time"
+)
+
+// optimizationHorizon is how far ahead OptimizeRoomAssignments moves stays.
+const optimizationHorizon = 365 * 24 * time.Hour
+
+// RoomMove is a reservation moved to another room of its type.
+type RoomMove struct {
+	ReservationID uint `json:"reservation_id"`
+	FromRoomID    uint `json:"from_room_id"`
+	ToRoomID      uint `json:"to_room_id"`
+}
+
+// OptimizeRoomAssignments repacks the auto-assigned reservations arriving
+// after today onto the rooms of the type they were booked as, so that stays
+// follow each other in as few rooms as possible, which keeps whole rooms
+// free for long stays instead of scattering single free nights over every
+// room. Stays are placed in order of arrival on the room with the shortest
+// gap since its previous stay, with strategy breaking ties; guests with
+// preferences only go to the rooms matching them best. A type whose stays
+// cannot all be placed is left as it is. Moves are written like any update
+// of SaveReservation: they keep the price of the stay, raise its Version and
+// are added to its history as made by user 0, the system.
+func OptimizeRoomAssignments(store repository.Store, strategy RoomAssignmentStrategy) ([]RoomMove, error) {
+	var moves []RoomMove
+	err := store.Transaction(func(tx repository.Store) error {
+		moves = nil
+		rooms, err := tx.Rooms().FindAll()
+		if err != nil {
+			return err
+		}
+		// Bookings lock the room they take, so locking every room keeps
+		// the schedule still while it is repacked.
+		for _, room := range rooms {
+			if _, err := tx.Rooms().FindByIDForUpdate(room.ID); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		horizon := now.Add(optimizationHorizon)
+		reservations, err := tx.Reservations().FindOverlapping(now, horizon, BlockingStatuses)
+		if err != nil {
+			return err
+		}
+
+		roomsByType := make(map[uint][]models.Room)
+		for _, room := range rooms {
+			roomsByType[room.RoomTypeID] = append(roomsByType[room.RoomTypeID], room)
+		}
+
+		// Stays that cannot move are scheduled room by room, segment by
+		// segment for guests who already moved.
+		tomorrow := dayOf(now).AddDate(0, 0, 1)
+		schedule := make(map[uint][]models.ReservationSegment)
+		movableByType := make(map[uint][]models.Reservation)
+		for _, reservation := range reservations {
+			if reservation.AutoAssigned && (reservation.Status == "pending" || reservation.Status == "confirmed") &&
+				!reservation.StartDate.Before(tomorrow) && !reservation.EndDate.After(horizon) {
+				movableByType[reservation.RoomTypeID] = append(movableByType[reservation.RoomTypeID], reservation)
+				continue
+			}
+			for _, stay := range roomStays(&reservation) {
+				schedule[stay.RoomID] = append(schedule[stay.RoomID], stay)
+			}
+		}
+
+		typeIDs := make([]uint, 0, len(movableByType))
+		for typeID := range movableByType {
+			typeIDs = append(typeIDs, typeID)
+		}
+		sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+
+		for _, typeID := range typeIDs {
+			typeMoves, err := repackRoomType(tx, strategy, roomsByType[typeID], movableByType[typeID], schedule)
+			if err != nil {
+				return err
+			}
+			moves = append(moves, typeMoves...)
+		}
+		return nil
+	})
+	return moves, err
+}
+
+// repackRoomType places the movable stays of one room type around the
+// stays already in schedule, which it adds them to, and saves the stays
+// that end up in another room.
+func repackRoomType(store repository.Store, strategy RoomAssignmentStrategy, rooms []models.Room, movable []models.Reservation, schedule map[uint][]models.ReservationSegment) ([]RoomMove, error) {
+	inService := make([]models.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if room.Status != models.RoomOutOfService {
+			inService = append(inService, room)
+		}
+	}
+
+	sort.Slice(movable, func(i, j int) bool {
+		if !movable[i].StartDate.Equal(movable[j].StartDate) {
+			return movable[i].StartDate.Before(movable[j].StartDate)
+		}
+		return movable[i].ID < movable[j].ID
+	})
+
+	placed := make(map[uint]uint, len(movable))
+	for i := range movable {
+		stay := &movable[i]
+		ranked, err := strategy.Rank(store, stay, inService)
+		if err != nil {
+			return nil, err
+		}
+		var fitting []models.Room
+		for _, room := range ranked {
+			if fitsSchedule(schedule[room.ID], stay) {
+				fitting = append(fitting, room)
+			}
+		}
+		if preferences := ParsePreferences(stay.Preferences); len(preferences) > 0 {
+			fitting = bestMatches(fitting, preferenceScores(preferences, inService))
+		}
+		if len(fitting) == 0 {
+			return nil, nil
+		}
+
+		best := fitting[0]
+		for _, room := range fitting[1:] {
+			if gapBefore(schedule[room.ID], stay) < gapBefore(schedule[best.ID], stay) {
+				best = room
+			}
+		}
+		schedule[best.ID] = append(schedule[best.ID], models.ReservationSegment{RoomID: best.ID, StartDate: stay.StartDate, EndDate: stay.EndDate})
+		placed[stay.ID] = best.ID
+	}
+
+	var moves []RoomMove
+	now := time.Now()
+	for _, stay := range movable {
+		if placed[stay.ID] == stay.RoomID {
+			continue
+		}
+		reservation, err := store.Reservations().FindByIDForUpdate(stay.ID)
+		if err != nil {
+			return nil, err
+		}
+		before := *reservation
+		reservation.RoomID = placed[stay.ID]
+		reservation.UpdatedAt = now
+		// The schedule as a whole was checked above, while SaveReservation
+		// would check each move against rooms the others have not left yet.
+		if err := storeReservation(store, &before, reservation, 0); err != nil {
+			return nil, err
+		}
+		moves = append(moves, RoomMove{ReservationID: stay.ID, FromRoomID: before.RoomID, ToRoomID: reservation.RoomID})
+	}
+	return moves, nil
+}
+
+// fitsSchedule reports whether stay overlaps none of the stays of a room.
+func fitsSchedule(schedule []models.ReservationSegment, stay *models.Reservation) bool {
+	for _, other := range schedule {
+		if other.StartDate.Before(stay.EndDate) && other.EndDate.After(stay.StartDate) {
+			return false
+		}
+	}
+	return true
+}
+
+// gapBefore is the time a room stands empty between its previous stay and
+// stay, the longest possible duration when nothing comes before.
+func gapBefore(schedule []models.ReservationSegment, stay *models.Reservation) time.Duration {
+	gap := time.Duration(math.MaxInt64)
+	for _, other := range schedule {
+		if !other.EndDate.After(stay.StartDate) && stay.StartDate.Sub(other.EndDate) < gap {
+			gap = stay.StartDate.Sub(other.EndDate)
+		}
+	}
+	return gap
+}
+
+// bestMatches keeps the rooms with the highest score, in order.
+func bestMatches(rooms []models.Room, scores map[uint]int) []models.Room {
+	best := 0
+	for _, room := range rooms {
+		best = max(best, scores[room.ID])
+	}
+	var matches []models.Room
+	for _, room := range rooms {
+		if scores[room.ID] == best {
+			matches = append(matches, room)
+		}
+	}
+	return matches
+}
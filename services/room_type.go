@@ -0,0 +1,131 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRoomType = errors.New("invalid room type")
+	ErrUnknownRoomType = errors.New("unknown room type")
+	ErrRoomTypeInUse   = errors.New("room type still has rooms")
+	ErrNoRoomAvailable = errors.New("no room of the type is available for the requested dates")
+)
+
+// NormalizeRoomType checks a room type for the catalog, defaulting its
+// currency to the base currency and its base occupancy to its maximum.
+func NormalizeRoomType(roomType *models.RoomType) error {
+	roomType.Name = strings.TrimSpace(roomType.Name)
+	if roomType.Name == "" || roomType.BasePrice < 0 {
+		return ErrInvalidRoomType
+	}
+	currency, err := NormalizeCurrency(roomType.Currency)
+	if err != nil {
+		return err
+	}
+	roomType.Currency = currency
+	if roomType.Amenities == nil {
+		roomType.Amenities = []string{}
+	}
+	if roomType.Photos == nil {
+		roomType.Photos = []string{}
+	}
+	return NormalizeRoomOccupancy(roomType)
+}
+
+// FindRoomType looks up a room type by ID, returning ErrUnknownRoomType when
+// there is none.
+func FindRoomType(store repository.Store, id uint) (*models.RoomType, error) {
+	roomType, err := store.RoomTypes().FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrUnknownRoomType
+	}
+	return roomType, err
+}
+
+// DeleteRoomType removes a room type from the catalog together with its
+// rate overrides. Types that rooms still belong to cannot be deleted.
+func DeleteRoomType(store repository.Store, id uint) error {
+	return store.Transaction(func(tx repository.Store) error {
+		if _, err := FindRoomType(tx, id); err != nil {
+			return err
+		}
+		rooms, err := tx.Rooms().FindByRoomType(id)
+		if err != nil {
+			return err
+		}
+		if len(rooms) > 0 {
+			return ErrRoomTypeInUse
+		}
+
+		overrides, err := tx.Rates().FindOverridesByRoomType(id)
+		if err != nil {
+			return err
+		}
+		for _, override := range overrides {
+			if err := tx.Rates().DeleteOverride(override.ID); err != nil {
+				return err
+			}
+		}
+		return tx.RoomTypes().Delete(id)
+	})
+}
+
+// FreeRooms returns the rooms of a type that are in service and free for
+// the whole of [start, end), ordered by number.
+func FreeRooms(store repository.Store, roomTypeID uint, start, end time.Time) ([]models.Room, error) {
+	if !end.After(start) {
+		return nil, ErrInvalidDateRange
+	}
+	rooms, err := store.Rooms().FindByRoomType(roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	occupied, err := OccupiedRooms(store, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return freeRooms(rooms, occupied), nil
+}
+
+// freeRooms keeps the rooms that are in service and not occupied.
+func freeRooms(rooms []models.Room, occupied map[uint]bool) []models.Room {
+	free := make([]models.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if !occupied[room.ID] && room.Status != models.RoomOutOfService {
+			free = append(free, room)
+		}
+	}
+	return free
+}
+
+// BookRoomType creates a reservation for a stay in any room of its
+// RoomTypeID. The free rooms are tried in the order strategy ranks them, so
+// a room taken by a concurrent booking in the meantime is skipped;
+// ErrNoRoomAvailable is returned when none is left. The reservation is
+// marked as auto-assigned.
+func BookRoomType(store repository.Store, strategy RoomAssignmentStrategy, reservation *models.Reservation, actorID uint) error {
+	rooms, err := FreeRooms(store, reservation.RoomTypeID, reservation.StartDate, reservation.EndDate)
+	if err != nil {
+		return err
+	}
+	rooms, err = strategy.Rank(store, reservation, rooms)
+	if err != nil {
+		return err
+	}
+	reservation.AutoAssigned = true
+	for _, room := range rooms {
+		reservation.RoomID = room.ID
+		err := SaveReservation(store, reservation, actorID)
+		if errors.Is(err, ErrRoomUnavailable) || errors.Is(err, ErrRoomOutOfService) {
+			continue
+		}
+		return err
+	}
+	reservation.RoomID = 0
+	reservation.AutoAssigned = false
+	return ErrNoRoomAvailable
+}
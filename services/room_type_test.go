@@ -0,0 +1,195 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"testing"
+)
+
+// addSuite adds a suite type sleeping four at 300.00 USD a night, with room
+// 301, to a store made by newTestStore.
+func addSuite(t *testing.T, store repository.Store) *models.RoomType {
+	t.Helper()
+	suite := &models.RoomType{Name: "suite", BasePrice: 30000, Currency: "USD", MaxOccupancy: 4, BaseOccupancy: 4}
+	if err := store.RoomTypes().Create(suite); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Rooms().Create(&models.Room{Number: "301", RoomTypeID: suite.ID, Status: "available"}); err != nil {
+		t.Fatal(err)
+	}
+	return suite
+}
+
+func TestNormalizeRoomType(t *testing.T) {
+	tests := []struct {
+		name     string
+		roomType models.RoomType
+		currency string
+		err      error
+	}{
+		{name: "currency defaults to the base currency", roomType: models.RoomType{Name: " double ", BasePrice: 10000, MaxOccupancy: 2}, currency: BaseCurrency()},
+		{name: "currency upper-cased", roomType: models.RoomType{Name: "double", BasePrice: 10000, Currency: "eur", MaxOccupancy: 2}, currency: "EUR"},
+		{name: "missing name", roomType: models.RoomType{Name: " ", BasePrice: 10000, MaxOccupancy: 2}, err: ErrInvalidRoomType},
+		{name: "negative price", roomType: models.RoomType{Name: "double", BasePrice: -1, MaxOccupancy: 2}, err: ErrInvalidRoomType},
+		{name: "invalid currency", roomType: models.RoomType{Name: "double", BasePrice: 10000, Currency: "EURO", MaxOccupancy: 2}, err: ErrInvalidCurrency},
+		{name: "no occupancy", roomType: models.RoomType{Name: "double", BasePrice: 10000}, err: ErrInvalidOccupancy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NormalizeRoomType(&tt.roomType)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if err == nil && (tt.roomType.Name != "double" || tt.roomType.Currency != tt.currency) {
+				t.Errorf("got %q in %s, want \"double\" in %s", tt.roomType.Name, tt.roomType.Currency, tt.currency)
+			}
+		})
+	}
+}
+
+func TestDeleteRoomType(t *testing.T) {
+	store := newTestStore(t)
+	empty := &models.RoomType{Name: "single", BasePrice: 8000, Currency: "USD", MaxOccupancy: 1}
+	if err := store.RoomTypes().Create(empty); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Rates().CreateOverride(&models.RateOverride{RoomTypeID: empty.ID, Weekdays: "sat", Amount: 9000}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteRoomType(store, 1); !errors.Is(err, ErrRoomTypeInUse) {
+		t.Errorf("deleting a type with rooms: got %v, want %v", err, ErrRoomTypeInUse)
+	}
+	if err := DeleteRoomType(store, empty.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindRoomType(store, empty.ID); !errors.Is(err, ErrUnknownRoomType) {
+		t.Errorf("got %v, want %v", err, ErrUnknownRoomType)
+	}
+	if overrides, _ := store.Rates().FindOverridesByRoomType(empty.ID); len(overrides) != 0 {
+		t.Errorf("got %d overrides left, want the type's overrides deleted", len(overrides))
+	}
+	if err := DeleteRoomType(store, empty.ID); !errors.Is(err, ErrUnknownRoomType) {
+		t.Errorf("deleting again: got %v, want %v", err, ErrUnknownRoomType)
+	}
+}
+
+// TestBookRoomType books the double type until no room is left. Room 101 is
+// out of service, so the bookings land in 102 and then find nothing.
+func TestBookRoomType(t *testing.T) {
+	store := newTestStore(t)
+	room, err := store.Rooms().FindByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	room.Status = models.RoomOutOfService
+	if err := store.Rooms().Save(room); err != nil {
+		t.Fatal(err)
+	}
+
+	var rooms []uint
+	for i := 0; i < 2; i++ {
+		reservation := &models.Reservation{UserID: 1, RoomTypeID: 1, StartDate: day(t, "2026-01-05"), EndDate: day(t, "2026-01-07"), Status: "pending", Adults: 1}
+		plan, err := FindRatePlan(store, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := PriceReservation(store, reservation, plan); err != nil {
+			t.Fatal(err)
+		}
+		err = BookRoomType(store, LowestFloorStrategy{}, reservation, 1)
+		if errors.Is(err, ErrNoRoomAvailable) {
+			if reservation.RoomID != 0 {
+				t.Errorf("refused booking kept room %d", reservation.RoomID)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reservation.TotalAmount != 20000 {
+			t.Errorf("got total %d, want the type's 20000", reservation.TotalAmount)
+		}
+		rooms = append(rooms, reservation.RoomID)
+	}
+	if fmt.Sprint(rooms) != "[2]" {
+		t.Errorf("got rooms %v, want only room 102", rooms)
+	}
+}
+
+func TestSearchAvailability(t *testing.T) {
+	store := newTestStore(t)
+	addSuite(t, store)
+	book(t, store, 1, "2026-01-05", "2026-01-07")
+
+	tests := []struct {
+		name  string
+		query AvailabilityQuery
+		want  string
+	}{
+		{name: "every type with a free room", want: "[double 20000 [102] suite 60000 [301]]"},
+		{name: "by type", query: AvailabilityQuery{Type: "suite"}, want: "[suite 60000 [301]]"},
+		{name: "too many guests for a double", query: AvailabilityQuery{Guests: 2, Children: 1}, want: "[suite 60000 [301]]"},
+		{name: "within the maximum price", query: AvailabilityQuery{MaxPrice: 10000}, want: "[double 20000 [102]]"},
+		{name: "nothing that cheap", query: AvailabilityQuery{MaxPrice: 9999}, want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.StartDate, tt.query.EndDate = day(t, "2026-01-05"), day(t, "2026-01-07")
+			types, err := SearchAvailability(store, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, roomType := range types {
+				numbers := make([]string, 0, len(roomType.Rooms))
+				for _, room := range roomType.Rooms {
+					numbers = append(numbers, room.Number)
+				}
+				got = append(got, fmt.Sprintf("%s %d %v", roomType.Type, roomType.TotalPrice, numbers))
+			}
+			if fmt.Sprint(got) != tt.want {
+				t.Errorf("got %v, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := SearchAvailability(store, AvailabilityQuery{StartDate: day(t, "2026-01-07"), EndDate: day(t, "2026-01-05")}); !errors.Is(err, ErrInvalidDateRange) {
+		t.Errorf("got %v, want %v", err, ErrInvalidDateRange)
+	}
+}
+
+// TestSearchAvailabilityDearestNight prices the Tuesday of a Monday to
+// Wednesday stay higher: the type is quoted and filtered at that night.
+func TestSearchAvailabilityDearestNight(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.Rates().CreateOverride(&models.RateOverride{RoomTypeID: 1, Weekdays: "tue", Amount: 15000}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		maxPrice int64
+		want     string
+	}{
+		{maxPrice: 0, want: "[double 15000 25000]"},
+		{maxPrice: 15000, want: "[double 15000 25000]"},
+		{maxPrice: 12500, want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.maxPrice), func(t *testing.T) {
+			types, err := SearchAvailability(store, AvailabilityQuery{StartDate: day(t, "2026-01-05"), EndDate: day(t, "2026-01-07"), MaxPrice: tt.maxPrice})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, roomType := range types {
+				got = append(got, fmt.Sprintf("%s %d %d", roomType.Type, roomType.Price, roomType.TotalPrice))
+			}
+			if fmt.Sprint(got) != tt.want {
+				t.Errorf("got %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"hotel_management_system/models"
+	"hotel_management_system/repository"
+	"math"
+	"time"
+)
+
+var ErrInvalidTax = errors.New("invalid tax")
+
+var taxKinds = map[string]bool{
+	"percentage":      true,
+	"per-night":       true,
+	"per-guest-night": true,
+}
+
+// ValidateTax checks that a tax has a known kind and a rate or amount that
+// makes sense for it.
+func ValidateTax(tax *models.Tax) error {
+	if tax.Code == "" || tax.Name == "" || !taxKinds[tax.Kind] {
+		return ErrInvalidTax
+	}
+	if tax.Kind == "percentage" {
+		if tax.Rate <= 0 || tax.Rate >= 100 {
+			return ErrInvalidTax
+		}
+		tax.Amount = 0
+		return nil
+	}
+	if tax.Amount <= 0 {
+		return ErrInvalidTax
+	}
+	tax.Rate = 0
+	return nil
+}
+
+// taxNight computes what tax charges on a night sold for amount to guests.
+// An inclusive percentage is carved out of the amount, an exclusive one is
+// charged on top of it. Fixed taxes are the same either way.
+func taxNight(tax models.Tax, amount int64, guests int) int64 {
+	switch tax.Kind {
+	case "percentage":
+		if tax.Inclusive {
+			return amount - int64(math.Round(float64(amount)/(1+tax.Rate/100)))
+		}
+		return int64(math.Round(float64(amount) * tax.Rate / 100))
+	case "per-night":
+		return tax.Amount
+	case "per-guest-night":
+		return tax.Amount * int64(guests)
+	}
+	return 0
+}
+
+// TaxStay computes every tax charged on each night of a stay.
+func TaxStay(taxes []models.Tax, nights []models.ReservationNight, guests int) []models.ReservationTax {
+	var lines []models.ReservationTax
+	for _, night := range nights {
+		for _, tax := range taxes {
+			amount := taxNight(tax, night.Amount, guests)
+			if amount == 0 {
+				continue
+			}
+			lines = append(lines, models.ReservationTax{
+				TaxID:     tax.ID,
+				Name:      tax.Name,
+				Date:      night.Date,
+				Amount:    amount,
+				Inclusive: tax.Inclusive,
+			})
+		}
+	}
+	return lines
+}
+
+// SumTaxes returns the total of taxes and the part of it that is charged
+// on top of the room price.
+func SumTaxes(taxes []models.ReservationTax) (total, exclusive int64) {
+	for _, tax := range taxes {
+		total += tax.Amount
+		if !tax.Inclusive {
+			exclusive += tax.Amount
+		}
+	}
+	return total, exclusive
+}
+
+// ActiveTaxes returns the taxes charged on new bookings with their fixed
+// amounts converted from the base currency to currency at today's rates.
+func ActiveTaxes(store repository.Store, currency string) ([]models.Tax, error) {
+	taxes, err := store.Taxes().FindActive()
+	if err != nil {
+		return nil, err
+	}
+	conv := newConverter(store)
+	for i := range taxes {
+		taxes[i].Amount, err = conv.convert(taxes[i].Amount, BaseCurrency(), currency, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return taxes, nil
+}
+
+// stayTotal returns the gross total of a stay for guests under taxes, and
+// the taxes it includes.
+func stayTotal(taxes []models.Tax, nights []models.ReservationNight, guests int) (int64, []models.ReservationTax) {
+	lines := TaxStay(taxes, nights, guests)
+	_, exclusive := SumTaxes(lines)
+	return SumNights(nights) + exclusive, lines
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hotel_management_system/models"
+	"testing"
+)
+
+func TestValidateTax(t *testing.T) {
+	tests := []struct {
+		name string
+		tax  models.Tax
+		err  error
+	}{
+		{name: "percentage", tax: models.Tax{Code: "vat", Name: "VAT", Kind: "percentage", Rate: 10}},
+		{name: "per night", tax: models.Tax{Code: "city", Name: "City tax", Kind: "per-night", Amount: 200}},
+		{name: "per guest night", tax: models.Tax{Code: "tourism", Name: "Tourism tax", Kind: "per-guest-night", Amount: 150}},
+		{name: "missing code", tax: models.Tax{Name: "VAT", Kind: "percentage", Rate: 10}, err: ErrInvalidTax},
+		{name: "unknown kind", tax: models.Tax{Code: "vat", Name: "VAT", Kind: "per-stay", Amount: 200}, err: ErrInvalidTax},
+		{name: "percentage without rate", tax: models.Tax{Code: "vat", Name: "VAT", Kind: "percentage", Amount: 200}, err: ErrInvalidTax},
+		{name: "percentage of 100", tax: models.Tax{Code: "vat", Name: "VAT", Kind: "percentage", Rate: 100}, err: ErrInvalidTax},
+		{name: "fixed without amount", tax: models.Tax{Code: "city", Name: "City tax", Kind: "per-night", Rate: 5}, err: ErrInvalidTax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTax(&tt.tax); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTaxNight(t *testing.T) {
+	tests := []struct {
+		name   string
+		tax    models.Tax
+		amount int64
+		guests int
+		want   int64
+	}{
+		{name: "exclusive percentage", tax: models.Tax{Kind: "percentage", Rate: 10}, amount: 10000, want: 1000},
+		{name: "exclusive percentage rounds half up", tax: models.Tax{Kind: "percentage", Rate: 10}, amount: 10005, want: 1001},
+		{name: "inclusive percentage", tax: models.Tax{Kind: "percentage", Rate: 10, Inclusive: true}, amount: 11000, want: 1000},
+		{name: "inclusive percentage rounds the net", tax: models.Tax{Kind: "percentage", Rate: 10, Inclusive: true}, amount: 10000, want: 909},
+		{name: "per night", tax: models.Tax{Kind: "per-night", Amount: 250}, amount: 10000, guests: 3, want: 250},
+		{name: "per guest night", tax: models.Tax{Kind: "per-guest-night", Amount: 150}, amount: 10000, guests: 3, want: 450},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taxNight(tt.tax, tt.amount, tt.guests); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestStayTotal checks that only exclusive taxes are added to the price of
+// the nights, while every tax is itemized.
+func TestStayTotal(t *testing.T) {
+	taxes := []models.Tax{
+		{ID: 1, Name: "VAT", Kind: "percentage", Rate: 10, Inclusive: true},
+		{ID: 2, Name: "Tourism tax", Kind: "per-guest-night", Amount: 100},
+	}
+	nights := []models.ReservationNight{
+		{Date: day(t, "2026-01-05"), Amount: 10000},
+		{Date: day(t, "2026-01-06"), Amount: 10000},
+	}
+
+	total, lines := stayTotal(taxes, nights, 2)
+	if total != 20400 {
+		t.Errorf("got total %d, want 20400", total)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("got %d tax lines, want one per tax and night", len(lines))
+	}
+	taxTotal, exclusive := SumTaxes(lines)
+	if taxTotal != 2218 || exclusive != 400 {
+		t.Errorf("got taxes %d of which %d exclusive, want 2218 of which 400", taxTotal, exclusive)
+	}
+}
+
+func TestCheckOutInvoiceTaxes(t *testing.T) {
+	store := newTestStore(t)
+	for _, tax := range []models.Tax{
+		{Code: "vat", Name: "VAT", Kind: "percentage", Rate: 10, Inclusive: true, Active: true},
+		{Code: "city", Name: "City tax", Kind: "per-night", Amount: 200, Active: true},
+		{Code: "old", Name: "Old tax", Kind: "per-night", Amount: 999, Active: false},
+	} {
+		if err := store.Taxes().Create(&tax); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reservation := checkIn(t, store, 1)
+	if reservation.TotalAmount != 20400 || reservation.TaxAmount != 2218 {
+		t.Fatalf("priced at %d with %d of tax, want 20400 with 2218", reservation.TotalAmount, reservation.TaxAmount)
+	}
+	payment := models.FolioLine{Type: "credit", Category: "payment", Description: "Cash", Amount: 20400}
+	if err := PostCharge(store, reservation.ID, &payment, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CheckOut(store, reservation.ID, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, err := FindInvoiceByReservation(store, reservation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, line := range invoice.Lines {
+		if line.Kind == "tax" {
+			lines = append(lines, fmt.Sprintf("%s %d", line.Description, line.Amount))
+		}
+	}
+	if fmt.Sprint(lines) != "[VAT (included) 1818 City tax 400]" {
+		t.Errorf("got tax lines %v, want VAT included and the city tax summed over the stay", lines)
+	}
+	if invoice.Subtotal != 18182 || invoice.TaxTotal != 2218 || invoice.Total != 20400 || invoice.BalanceDue != 0 {
+		t.Errorf("got subtotal %d, tax %d, total %d, due %d, want 18182, 2218, 20400, 0",
+			invoice.Subtotal, invoice.TaxTotal, invoice.Total, invoice.BalanceDue)
+	}
+}